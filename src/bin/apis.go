@@ -31,138 +31,341 @@
 package main
 
 import (
+	"calendar"
 	"errors"
+	"list"
+	"name"
 	"net/url"
 	"strconv"
+	"texts"
+	"time"
 	"unicode/utf8"
 	"unihan"
+	"utils"
 
 	"github.com/valyala/fasthttp"
 )
 
-/*
-func apiSolar(ctx *fasthttp.RequestCtx) {
-	defer func() {
-		if x := recover(); x != nil {
-			ctx.SetUserValue("_envelope_code", 20011)
-			ctx.SetUserValue("_envelope_message", "Timestamp overflow")
-			ctx.SetUserValue("_envelope_data", nil)
-		}
-	}()
-
-	timestamp, _ := strconv.ParseInt(ctx.UserValue("time").(string), 10, 64)
-	c := calendar.ByTimestamp(timestamp)
-	type retSolar struct {
-		Animal    string `json:"animal"`
-		Year      int64  `json:"year"`
-		Month     int64  `json:"month"`
-		Day       int64  `json:"day"`
-		Hour      int64  `json:"hour"`
-		Minute    int64  `json:"minute"`
-		Second    int64  `json:"second"`
-		Week      int64  `json:"week"`
-		WeekAlias string `json:"week_alias"`
-		Leap      bool   `json:"leap"`
-	}
-
-	r := &retSolar{
-		Animal:    c.Solar.Animal().Alias(),
-		Year:      c.Solar.GetYear(),
-		Month:     c.Solar.GetMonth(),
-		Day:       c.Solar.GetDay(),
-		Hour:      c.Solar.GetHour(),
-		Minute:    c.Solar.GetMinute(),
-		Second:    c.Solar.GetSecond(),
-		Week:      c.Solar.WeekNumber(),
-		WeekAlias: c.Solar.WeekAlias(),
-		Leap:      c.Solar.IsLeep(),
-	}
-
-	ctx.SetUserValue("_envelope_data", r)
+func apiCalendar(ctx *fasthttp.RequestCtx) {
+	var (
+		args  = ctx.QueryArgs()
+		loc   utils.Location
+		birth *birthArgs
+		opts  calendar.Options
+		err   error
+	)
+
+	loc, err = parseLocation(args)
+	if err != nil {
+		birthError(ctx, err)
+
+		return
+	}
+
+	birth, err = parseBirth(args, loc)
+	if err != nil {
+		birthError(ctx, err)
+
+		return
+	}
+
+	opts, err = parseCalendarOptions(args, birth)
+	if err != nil {
+		birthError(ctx, err)
+
+		return
+	}
+
+	c := calendar.NewWithOptions(birth.timestamp, loc, opts)
+	c.SetInputZone(birth.zone)
+	c.Localize(texts.AssertLanguage(string(args.Peek("lang"))))
+
+	ctx.SetUserValue("_envelope_data", c)
 
 	return
 }
 
-func apiLunar(ctx *fasthttp.RequestCtx) {
-	defer func() {
-		if x := recover(); x != nil {
-			ctx.SetUserValue("_envelope_code", 20011)
-			ctx.SetUserValue("_envelope_message", "Timestamp overflow")
-			ctx.SetUserValue("_envelope_data", nil)
-		}
-	}()
-
-	timestamp, _ := strconv.ParseInt(ctx.UserValue("time").(string), 10, 64)
-	c := calendar.ByTimestamp(timestamp)
-	type retLunar struct {
-		Animal     string `json:"animal"`
-		Year       int64  `json:"year"`
-		YearAlias  string `json:"year_str"`
-		Month      int64  `json:"month"`
-		MonthAlias string `json:"month_str"`
-		Day        int64  `json:"day"`
-		DayAlias   string `json:"day_str"`
-		Leap       bool   `json:"leap"`
-		LeapMonth  bool   `json:"leap_month"`
-	}
-
-	r := &retLunar{
-		Animal:     c.Lunar.Animal().Alias(),
-		Year:       c.Lunar.GetYear(),
-		Month:      c.Lunar.GetMonth(),
-		Day:        c.Lunar.GetDay(),
-		YearAlias:  c.Lunar.YearAlias(),
-		MonthAlias: c.Lunar.MonthAlias(),
-		DayAlias:   c.Lunar.DayAlias(),
-		Leap:       c.Lunar.IsLeap(),
-		LeapMonth:  c.Lunar.IsLeapMonth(),
-	}
-
-	ctx.SetUserValue("_envelope_data", r)
+func apiCalendarICS(ctx *fasthttp.RequestCtx) {
+	var (
+		args     = ctx.QueryArgs()
+		language = texts.AssertLanguage(string(args.Peek("lang")))
+		loc      utils.Location
+		birth    *birthArgs
+		opts     calendar.Options
+		events   []*calendar.ICSEvent
+		more     []*calendar.ICSEvent
+		err      error
+	)
+
+	loc, err = parseLocation(args)
+	if err == nil {
+		birth, err = parseBirth(args, loc)
+	}
+
+	if err == nil {
+		opts, err = parseCalendarOptions(args, birth)
+	}
+
+	if err != nil {
+		ctx.Error(err.Error(), fasthttp.StatusBadRequest)
+
+		return
+	}
+
+	// Lunar birth date, of China time
+	c := calendar.NewWithOptions(birth.timestamp, loc, opts)
+	lunar := calendar.LunarDate{
+		Year:  c.Lunar.Year,
+		Month: c.Lunar.Month,
+		Day:   c.Lunar.Day,
+		Leap:  c.Lunar.LeapMonth,
+	}
+
+	fromYear := args.GetUintOrZero("from_year")
+	if fromYear <= 0 {
+		fromYear = calendar.New(time.Now().Unix(), loc).ChinaTime.Year
+	}
+
+	years := args.GetUintOrZero("years")
+	if years <= 0 {
+		years = calendar.ICSDefaultYears
+	}
+
+	if years > calendar.ICSMaxYears {
+		years = calendar.ICSMaxYears
+	}
+
+	events, err = calendar.LunarBirthdayEvents(lunar, fromYear, years, language)
+	if err == nil && args.GetBool("ben_ming") {
+		more, err = calendar.BenMingEvents(lunar.Year, fromYear, years, language)
+		events = append(events, more...)
+	}
+
+	if err == nil && args.GetBool("solarterms") {
+		more, err = calendar.SolartermEvents(fromYear, years, language)
+		events = append(events, more...)
+	}
+
+	if err != nil {
+		ctx.Error(err.Error(), fasthttp.StatusBadRequest)
+
+		return
+	}
+
+	ctx.SetContentType("text/calendar; charset=utf-8")
+	ctx.Response.Header.Set("Content-Disposition", "attachment; filename=\"birthday.ics\"")
+	ctx.Response.Header.Set("Access-Control-Allow-Origin", "*")
+	ctx.SetBody(calendar.RenderICS(events[0].Summary, events))
 
 	return
 }
 
-func apiGanzhi(ctx *fasthttp.RequestCtx) {
-	defer func() {
-		if x := recover(); x != nil {
-			ctx.SetUserValue("_envelope_code", 20011)
-			ctx.SetUserValue("_envelope_message", "Timestamp overflow")
-			ctx.SetUserValue("_envelope_data", nil)
+func apiBazi(ctx *fasthttp.RequestCtx) {
+	var (
+		args     = ctx.QueryArgs()
+		language = texts.AssertLanguage(string(args.Peek("lang")))
+		loc      utils.Location
+		birth    *birthArgs
+		opts     name.Options
+		err      error
+	)
+
+	loc, err = parseLocation(args)
+	if err == nil {
+		birth, err = parseBirth(args, loc)
+	}
+
+	if err == nil {
+		opts.Calendar, err = parseCalendarOptions(args, birth)
+	}
+
+	if err == nil {
+		opts.Favourable, err = parseFavourable(args)
+	}
+
+	if err != nil {
+		birthError(ctx, err)
+
+		return
+	}
+
+	opts.Gender = parseGender(args)
+	chart := name.GetChart(language, birth.timestamp, loc, opts)
+	chart.Calendar.SetInputZone(birth.zone)
+
+	window := int64(args.GetUintOrZero("window"))
+	if window > 0 {
+		// Cached chart shared, window alongside
+		ctx.SetUserValue("_envelope_data", struct {
+			*name.Chart
+			Window *name.ChartWindow `json:"window"`
+		}{
+			Chart:  chart,
+			Window: name.NewChartWindow(language, birth.timestamp-window, birth.timestamp+window, loc, opts),
+		})
+
+		return
+	}
+
+	ctx.SetUserValue("_envelope_data", chart)
+
+	return
+}
+
+func apiNameSplit(ctx *fasthttp.RequestCtx) {
+	splits := name.SplitFullName(string(ctx.QueryArgs().Peek("full")))
+	if len(splits) == 0 {
+		ctx.SetUserValue("_envelope_code", 10400)
+		ctx.SetUserValue("_envelope_message", "Invalid full name")
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+
+		return
+	}
+
+	ctx.SetUserValue("_envelope_data", splits)
+
+	return
+}
+
+func apiLuck(ctx *fasthttp.RequestCtx) {
+	var (
+		args     = ctx.QueryArgs()
+		language = texts.AssertLanguage(string(args.Peek("lang")))
+		loc      utils.Location
+		birth    *birthArgs
+		opts     calendar.Options
+		gender   int
+		err      error
+	)
+
+	loc, err = parseLocation(args)
+	if err == nil {
+		birth, err = parseBirth(args, loc)
+	}
+
+	if err == nil {
+		opts, err = parseCalendarOptions(args, birth)
+	}
+
+	gender = parseGender(args)
+	if err == nil && gender == utils.GenderUnknown {
+		err = errors.New("Gender required")
+	}
+
+	if err != nil {
+		birthError(ctx, err)
+
+		return
+	}
+
+	c := calendar.NewWithOptions(birth.timestamp, loc, opts)
+	fromYear := args.GetUintOrZero("from_year")
+	if fromYear == 0 {
+		fromYear = c.ChinaTime.Year
+	}
+
+	toYear := args.GetUintOrZero("to_year")
+	if toYear < fromYear {
+		toYear = fromYear + name.LuckAnnualDefault - 1
+	}
+
+	ctx.SetUserValue("_envelope_data", name.NewLuck(language, c, gender, fromYear, toYear))
+
+	return
+}
+
+func apiCalendarSolarterms(ctx *fasthttp.RequestCtx) {
+	var (
+		language = texts.AssertLanguage(string(ctx.QueryArgs().Peek("lang")))
+		year     int
+		ret      []*calendar.Solarterm
+		err      error
+	)
+
+	year, err = strconv.Atoi(ctx.UserValue("year").(string))
+	if err == nil {
+		ret, err = calendar.ListSolarterms(year, language)
+	}
+
+	if err != nil {
+		ctx.SetUserValue("_envelope_code", 10400)
+		ctx.SetUserValue("_envelope_message", "Invalid year")
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+
+		return
+	}
+
+	ctx.SetUserValue("_envelope_data", ret)
+
+	return
+}
+
+func apiCalendarMonth(ctx *fasthttp.RequestCtx) {
+	var (
+		language    = texts.AssertLanguage(string(ctx.QueryArgs().Peek("lang")))
+		year, month int
+		ret         []*calendar.MonthDay
+		err         error
+	)
+
+	year, err = strconv.Atoi(ctx.UserValue("year").(string))
+	if err == nil {
+		month, err = strconv.Atoi(ctx.UserValue("month").(string))
+	}
+
+	if err == nil {
+		ret, err = calendar.MonthView(year, month, language)
+	}
+
+	if err != nil {
+		ctx.SetUserValue("_envelope_code", 10400)
+		ctx.SetUserValue("_envelope_message", "Invalid year or month")
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+
+		return
+	}
+
+	ctx.SetUserValue("_envelope_data", ret)
+
+	return
+}
+
+func apiAlmanac(ctx *fasthttp.RequestCtx) {
+	var (
+		language         = texts.AssertLanguage(string(ctx.QueryArgs().Peek("lang")))
+		year, month, day int
+		ret              interface{}
+		err              error
+	)
+
+	year, err = strconv.Atoi(ctx.UserValue("year").(string))
+	if err == nil {
+		month, err = strconv.Atoi(ctx.UserValue("month").(string))
+	}
+
+	if err == nil {
+		if v, ok := ctx.UserValue("day").(string); ok {
+			day, err = strconv.Atoi(v)
+			if err == nil {
+				ret, err = calendar.AlmanacDay(year, month, day, language)
+			}
+		} else {
+			ret, err = calendar.AlmanacMonth(year, month, language)
 		}
-	}()
-
-	timestamp, _ := strconv.ParseInt(ctx.UserValue("time").(string), 10, 64)
-	c := calendar.ByTimestamp(timestamp)
-	type retGanzhi struct {
-		Animal     string `json:"animal"`
-		Year       string `json:"year"`
-		YearOrder  int64  `json:"year_order"`
-		Month      string `json:"month"`
-		MonthOrder int64  `json:"month_order"`
-		Day        string `json:"day"`
-		DayOrder   int64  `json:"day_order"`
-		Hour       string `json:"hour"`
-		HourOrder  int64  `json:"hour_order"`
-	}
-
-	r := &retGanzhi{
-		Animal:     c.Ganzhi.Animal().Alias(),
-		Year:       c.Ganzhi.YearGanzhiAlias(),
-		Month:      c.Ganzhi.MonthGanzhiAlias(),
-		Day:        c.Ganzhi.DayGanzhiAlias(),
-		Hour:       c.Ganzhi.HourGanzhiAlias(),
-		YearOrder:  c.Ganzhi.YearGanzhiOrder(),
-		MonthOrder: c.Ganzhi.MonthGanzhiOrder(),
-		DayOrder:   c.Ganzhi.DayGanzhiOrder(),
-		HourOrder:  c.Ganzhi.HourGanzhiOrder(),
-	}
-
-	ctx.SetUserValue("_envelope_data", r)
+	}
+
+	if err != nil {
+		ctx.SetUserValue("_envelope_code", 10400)
+		ctx.SetUserValue("_envelope_message", "Invalid date")
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+
+		return
+	}
+
+	ctx.SetUserValue("_envelope_data", ret)
 
 	return
 }
-*/
 
 func getRune(mode, input string) (rune, error) {
 	var (
@@ -237,10 +440,11 @@ func apiStroke(ctx *fasthttp.RequestCtx) {
 
 	ks, us, s, _ := h.QueryStroke()
 
-	ctx.SetUserValue("_envelope_data", map[string]int{
-		"stroke":         s,
-		"unicode_stroke": us,
-		"kangxi_stroke":  ks,
+	ctx.SetUserValue("_envelope_data", map[string]interface{}{
+		"stroke":            s,
+		"unicode_stroke":    us,
+		"kangxi_stroke":     ks,
+		"kangxi_derivation": h.QueryStrokeKangxi(),
 	})
 
 	return
@@ -270,6 +474,115 @@ func apiTraditional(ctx *fasthttp.RequestCtx) {
 	ctx.SetUserValue("_envelope_data", tsi)
 }
 
+func apiRealTime(ctx *fasthttp.RequestCtx) {
+	var (
+		args  = ctx.QueryArgs()
+		loc   utils.Location
+		birth *birthArgs
+		err   error
+	)
+
+	loc, err = parseLocation(args)
+	if err != nil {
+		birthError(ctx, err)
+
+		return
+	}
+
+	birth, err = parseBirth(args, loc)
+	if err != nil {
+		birthError(ctx, err)
+
+		return
+	}
+
+	ret := calendar.NewRealSun(birth.timestamp, loc)
+	ret.SetInputZone(birth.zone)
+
+	ctx.SetUserValue("_envelope_data", ret)
+
+	return
+}
+
+func apiPlace(ctx *fasthttp.RequestCtx) {
+	var (
+		mode     = ctx.UserValue("mode").(string)
+		input, _ = url.QueryUnescape(ctx.UserValue("input").(string))
+		limit    = ctx.QueryArgs().GetUintOrZero("limit")
+		places   []*list.Place
+	)
+
+	if !list.GazetteerLoaded() {
+		ctx.SetUserValue("_envelope_code", 10400)
+		ctx.SetUserValue("_envelope_message", "Place lookup unavailable")
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+
+		return
+	}
+
+	switch mode {
+	case "resolve":
+		if p := list.QueryPlaceByCode(input); p != nil {
+			places = []*list.Place{p}
+		} else {
+			places = list.QueryPlaces(input)
+		}
+	case "suggest":
+		if limit <= 0 || limit > 50 {
+			limit = 10
+		}
+
+		places = list.SuggestPlaces(input, limit)
+	default:
+		ctx.SetUserValue("_envelope_code", 10400)
+		ctx.SetUserValue("_envelope_message", "Invalid mode")
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+
+		return
+	}
+
+	if len(places) == 0 {
+		ctx.SetUserValue("_envelope_code", 10404)
+		ctx.SetUserValue("_envelope_message", "Place does not exists")
+		ctx.SetStatusCode(fasthttp.StatusNotFound)
+
+		return
+	}
+
+	ctx.SetUserValue("_envelope_data", places)
+
+	return
+}
+
+func apiCalendarProfiles(ctx *fasthttp.RequestCtx) {
+	ctx.SetUserValue("_envelope_data", map[string]interface{}{
+		"default":  calendar.ProfileDefault,
+		"profiles": calendar.ListProfiles(),
+	})
+
+	return
+}
+
+func apiNameOptions(ctx *fasthttp.RequestCtx) {
+	ctx.SetUserValue("_envelope_data", map[string]interface{}{
+		"favourable": map[string]interface{}{
+			"default":    name.FavourableDefault,
+			"strategies": name.ListFavourableStrategies(),
+		},
+		"stroke": map[string]interface{}{
+			"default":  unihan.StrokePolicyDefault,
+			"policies": unihan.ListStrokePolicies(),
+		},
+		"grid": map[string]interface{}{
+			"default":      name.GridSchoolDefault,
+			"dual_default": name.GridSchoolDualDefault,
+			"schools":      name.ListGridSchools(),
+		},
+	})
+
+	return
+}
+
 /*
  * Local variables:
  * tab-width: 4
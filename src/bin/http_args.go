@@ -0,0 +1,412 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file http_args.go
+ * @package main
+ */
+
+package main
+
+import (
+	"calendar"
+	"errors"
+	"fmt"
+	"list"
+	"math"
+	"name"
+	"strconv"
+	"strings"
+	"texts"
+	"time"
+	"unicode/utf8"
+	"unihan"
+	"utils"
+
+	"github.com/valyala/fasthttp"
+)
+
+const dateLayout = "2006-01-02"
+
+var datetimeLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	dateLayout,
+}
+
+// parseShichen : Shichen (0 - 11) by index or dizhi character (such as "辰" or "辰时"), -1 if empty
+func parseShichen(v []byte) (int, error) {
+	if len(v) == 0 {
+		return -1, nil
+	}
+
+	if i, err := strconv.Atoi(string(v)); err == nil {
+		if i < 0 || i > 11 {
+			return -1, errors.New("Invalid shichen")
+		}
+
+		return i, nil
+	}
+
+	r, _ := utf8.DecodeRune(v)
+	for _, lang := range []int{texts.LanguageSimplified, texts.LanguageTraditional} {
+		for i := 0; i < 12; i++ {
+			if string(r) == texts.GetAlias(texts.AliasZhi, i, lang) {
+				return i, nil
+			}
+		}
+	}
+
+	return -1, errors.New("Invalid shichen")
+}
+
+// parseLunar : Lunar date in YYYY-MM-DD
+func parseLunar(v []byte) (int, int, int, error) {
+	var (
+		parts = strings.Split(string(v), "-")
+		ymd   [3]int
+		err   error
+	)
+
+	if len(parts) != 3 {
+		return 0, 0, 0, errors.New("Invalid lunar date")
+	}
+
+	for i, part := range parts {
+		ymd[i], err = strconv.Atoi(part)
+		if err != nil {
+			return 0, 0, 0, errors.New("Invalid lunar date")
+		}
+	}
+
+	return ymd[0], ymd[1], ymd[2], nil
+}
+
+// birthArgs : Birth time parsed from arguments
+type birthArgs struct {
+	timestamp   int64
+	zone        *time.Location
+	hourUnknown bool
+}
+
+// parseProfile : Convention <profile>, with overrides of single flags <zi_split>, <animal_by_lichun>
+// and <solar_time> (real / mean / clock) on top of it. Name of profile suffixed by overrides
+func parseProfile(args *fasthttp.Args) (calendar.Profile, error) {
+	var overrides []string
+
+	p, ok := calendar.GetProfile(string(args.Peek("profile")))
+	if !ok {
+		return p, errors.New("Unknown calendar profile")
+	}
+
+	if v := args.Peek("zi_split"); v != nil {
+		p.ZiSplit = args.GetBool("zi_split")
+		overrides = append(overrides, "zi_split="+string(v))
+	}
+
+	if v := args.Peek("animal_by_lichun"); v != nil {
+		p.AnimalByLiChun = args.GetBool("animal_by_lichun")
+		overrides = append(overrides, "animal_by_lichun="+string(v))
+	}
+
+	if v := args.Peek("solar_time"); v != nil {
+		p.SolarTime, ok = calendar.ParseSolarTime(string(v))
+		if !ok {
+			return p, errors.New("Unknown solar time")
+		}
+
+		overrides = append(overrides, "solar_time="+string(v))
+	}
+
+	if len(overrides) > 0 {
+		p.Name += "+" + strings.Join(overrides, "+")
+	}
+
+	return p, nil
+}
+
+// parseCalendarOptions : Calendar options of birth, with convention profile
+func parseCalendarOptions(args *fasthttp.Args, birth *birthArgs) (calendar.Options, error) {
+	var (
+		ret = calendar.Options{HourUnknown: birth.hourUnknown}
+		err error
+	)
+
+	ret.Profile, err = parseProfile(args)
+
+	return ret, err
+}
+
+// parseDatetime : ISO-8601 local datetime in IANA zone (Asia/Shanghai if empty), explicit offset in datetime takes precedence.
+// Date without time is taken as noon with hour unknown
+func parseDatetime(v, zone []byte) (*birthArgs, error) {
+	var (
+		ret = &birthArgs{}
+		t   time.Time
+		err error
+	)
+
+	if len(zone) == 0 {
+		zone = []byte("Asia/Shanghai")
+	}
+
+	ret.zone, err = time.LoadLocation(string(zone))
+	if err != nil {
+		return nil, errors.New("Invalid time zone")
+	}
+
+	t, err = time.Parse(time.RFC3339, string(v))
+	if err == nil {
+		ret.timestamp = t.Unix()
+
+		return ret, nil
+	}
+
+	for _, layout := range datetimeLayouts {
+		t, err = time.ParseInLocation(layout, string(v), ret.zone)
+		if err == nil {
+			if layout == dateLayout {
+				t = time.Date(t.Year(), t.Month(), t.Day(), 12, 0, 0, 0, ret.zone)
+				ret.hourUnknown = true
+			}
+
+			ret.timestamp = t.Unix()
+
+			return ret, nil
+		}
+	}
+
+	return nil, errors.New("Invalid datetime")
+}
+
+// parseInstant : Unix timestamp, or local datetime in IANA zone, in range lunar calendar supports
+func parseInstant(v, zone []byte) (int64, error) {
+	var ts int64
+
+	ts, err := strconv.ParseInt(string(v), 10, 64)
+	if err != nil {
+		b, err := parseDatetime(v, zone)
+		if err != nil {
+			return 0, err
+		}
+
+		ts = b.timestamp
+	}
+
+	if !calendar.InLunarRange(ts) {
+		return 0, errors.New("Time out of supported range")
+	}
+
+	return ts, nil
+}
+
+// parseRunes : Runes of UTF-8 argument, stop at invalid
+func parseRunes(v []byte) []rune {
+	var ret []rune
+
+	for len(v) > 0 {
+		r, size := utf8.DecodeRune(v)
+		if size == 0 || r == utf8.RuneError {
+			break
+		}
+
+		ret = append(ret, r)
+		v = v[size:]
+	}
+
+	return ret
+}
+
+// parseBirth : Birth time, by unix timestamp <birth>, local datetime <datetime> with IANA <zone>,
+// or lunar date <lunar> with <leap> and <shichen>. <hour_unknown> marks the birth hour unknown,
+// as date only datetime or lunar without shichen do
+func parseBirth(args *fasthttp.Args, loc utils.Location) (*birthArgs, error) {
+	var (
+		ret = &birthArgs{}
+		v   []byte
+		err error
+	)
+
+	v = args.Peek("birth")
+	if v != nil {
+		ret.timestamp, err = strconv.ParseInt(string(v), 10, 64)
+		if err != nil {
+			return nil, errors.New("Invalid birth timestamp")
+		}
+	} else if v = args.Peek("datetime"); v != nil {
+		ret, err = parseDatetime(v, args.Peek("zone"))
+		if err != nil {
+			return nil, err
+		}
+	} else if v = args.Peek("lunar"); v != nil {
+		year, month, day, err := parseLunar(v)
+		if err != nil {
+			return nil, err
+		}
+
+		shichen, err := parseShichen(args.Peek("shichen"))
+		if err != nil {
+			return nil, err
+		}
+
+		profile, err := parseProfile(args)
+		if err != nil {
+			return nil, err
+		}
+
+		// Shichen in solar time of profile
+		ret.timestamp, err = calendar.LunarTimestamp(year, month, day, args.GetBool("leap"), shichen, loc, profile)
+		if err != nil {
+			return nil, err
+		}
+
+		ret.hourUnknown = shichen < 0
+	} else {
+		return nil, errors.New("Birth time required")
+	}
+
+	if !calendar.InLunarRange(ret.timestamp) {
+		return nil, errors.New("Birth time out of supported range")
+	}
+
+	if args.GetBool("hour_unknown") {
+		ret.hourUnknown = true
+	}
+
+	return ret, nil
+}
+
+// parseGender : Gender by <gender>, unknown if neither male nor female
+func parseGender(args *fasthttp.Args) int {
+	gender := args.GetUintOrZero("gender")
+	if gender != utils.GenderFemale && gender != utils.GenderMale {
+		gender = utils.GenderUnknown
+	}
+
+	return gender
+}
+
+// parseFavourable : Favourable-element strategy by <favourable>
+func parseFavourable(args *fasthttp.Args) (string, error) {
+	v := string(args.Peek("favourable"))
+	if !name.IsFavourableStrategy(v) {
+		return "", errors.New("Unknown favourable strategy")
+	}
+
+	return v, nil
+}
+
+// parseStroke : Stroke policy of five grids by <stroke>
+func parseStroke(args *fasthttp.Args) (string, error) {
+	v := string(args.Peek("stroke"))
+	if !unihan.IsStrokePolicy(v) {
+		return "", errors.New("Unknown stroke policy")
+	}
+
+	return v, nil
+}
+
+// parseGrid : Five-grid calculation school by <grid>
+func parseGrid(args *fasthttp.Args) (string, error) {
+	v := string(args.Peek("grid"))
+	if !name.IsGridSchool(v) {
+		return "", errors.New("Unknown grid school")
+	}
+
+	return v, nil
+}
+
+// parseCoordinate : Signed degrees within ±limit, 0 if empty
+func parseCoordinate(v []byte, limit float64) (float64, error) {
+	if len(v) == 0 {
+		return 0, nil
+	}
+
+	f, err := strconv.ParseFloat(string(v), 64)
+	if err != nil || math.IsNaN(f) || f < -limit || f > limit {
+		return 0, errors.New("Invalid coordinate")
+	}
+
+	return f, nil
+}
+
+// parseLocation : Location by administrative code or name <place> in gazetteer, or <longitude> and <latitude>
+func parseLocation(args *fasthttp.Args) (utils.Location, error) {
+	v := args.Peek("place")
+	if len(v) > 0 {
+		if !list.GazetteerLoaded() {
+			return utils.Location{}, errors.New("Place lookup unavailable, longitude and latitude required")
+		}
+
+		places := list.QueryPlace(string(v))
+		if len(places) == 0 {
+			return utils.Location{}, errors.New("Unknown place")
+		}
+
+		if len(places) > 1 {
+			var candidates []string
+			for _, p := range places {
+				candidates = append(candidates, p.Code+" "+p.FullName)
+			}
+
+			return utils.Location{}, fmt.Errorf("Ambiguous place, candidates: %s", strings.Join(candidates, ", "))
+		}
+
+		return places[0].Location(), nil
+	}
+
+	latitude, err := parseCoordinate(args.Peek("latitude"), 90)
+	if err != nil {
+		return utils.Location{}, errors.New("Invalid latitude")
+	}
+
+	longitude, err := parseCoordinate(args.Peek("longitude"), 180)
+	if err != nil {
+		return utils.Location{}, errors.New("Invalid longitude")
+	}
+
+	return utils.Location{
+		Latitude:  latitude,
+		Longitude: longitude,
+	}, nil
+}
+
+// birthError : Set envelope of invalid birth input
+func birthError(ctx *fasthttp.RequestCtx, err error) {
+	ctx.SetUserValue("_envelope_code", 10400)
+	ctx.SetUserValue("_envelope_message", err.Error())
+	ctx.SetStatusCode(fasthttp.StatusBadRequest)
+
+	return
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -32,9 +32,9 @@ package main
 
 import (
 	"common"
+	"errors"
 	"fmt"
 	"name"
-	"strconv"
 	"texts"
 	"time"
 	"unicode/utf8"
@@ -84,11 +84,14 @@ func nameRank(ctx *fasthttp.RequestCtx) {
 		middleNameRunes []rune
 		givenName       []byte
 		givenNameRunes  []rune
-		birthTime       int64
-		longitude       float64
-		latitude        float64
+		birth           *birthArgs
+		opts            name.Options
+		loc             utils.Location
+		window          int64
+		splits          []*name.NameSplit
 		language        []byte
 		languageCode    int
+		err             error
 	)
 
 	familyName = args.Peek("family")
@@ -133,13 +136,56 @@ func nameRank(ctx *fasthttp.RequestCtx) {
 		}
 	}
 
-	b := args.Peek("birth")
-	if b != nil {
-		birthTime, _ = strconv.ParseInt(string(b), 10, 64)
+	// Full name split into surname and given name, if not given separately
+	if len(familyNameRunes) == 0 && len(args.Peek("full")) > 0 {
+		splits = name.SplitFullName(string(args.Peek("full")))
+		if len(splits) == 0 {
+			birthError(ctx, errors.New("Invalid full name"))
+
+			return
+		}
+
+		familyNameRunes = []rune(splits[0].FamilyName)
+		givenNameRunes = []rune(splits[0].GivenName)
+	}
+
+	loc, err = parseLocation(args)
+	if err != nil {
+		birthError(ctx, err)
+
+		return
+	}
+
+	birth, err = parseBirth(args, loc)
+	if err != nil {
+		birthError(ctx, err)
+
+		return
+	}
+
+	opts.Calendar, err = parseCalendarOptions(args, birth)
+	if err != nil {
+		birthError(ctx, err)
+
+		return
+	}
+
+	opts.Gender = parseGender(args)
+	opts.Favourable, err = parseFavourable(args)
+	if err == nil {
+		opts.Stroke, err = parseStroke(args)
+	}
+
+	if err == nil {
+		opts.Grid, err = parseGrid(args)
+	}
+
+	if err != nil {
+		birthError(ctx, err)
+
+		return
 	}
 
-	longitude = args.GetUfloatOrZero("longitude")
-	latitude = args.GetUfloatOrZero("latitude")
 	language = args.Peek("lang")
 	languageCode = texts.AssertLanguage(string(language))
 
@@ -149,13 +195,32 @@ func nameRank(ctx *fasthttp.RequestCtx) {
 		familyNameRunes,
 		middleNameRunes,
 		givenNameRunes,
-		birthTime,
-		latitude,
-		longitude,
+		birth.timestamp,
+		loc.Latitude,
+		loc.Longitude,
 		languageCode)
-	n := name.NewNameRunes(familyNameRunes, middleNameRunes, givenNameRunes)
+	// Mother's surname makes dual surname (父姓+母姓)
+	motherNameRunes := parseRunes(args.Peek("mother"))
+	n := name.NewNameDual(familyNameRunes, motherNameRunes, middleNameRunes, givenNameRunes)
 	n.Normalize()
-	ret := name.Rank(languageCode, n, birthTime, utils.Location{Latitude: latitude, Longitude: longitude})
+	if len(motherNameRunes) > 0 && !n.ValidDualSurname() {
+		birthError(ctx, name.ErrInvalidDualSurname)
+
+		return
+	}
+
+	ret := name.Rank(languageCode, n, birth.timestamp, loc, opts)
+	if ret.Chart != nil {
+		ret.Calendar.SetInputZone(birth.zone)
+	}
+
+	ret.Splits = splits
+
+	// Birth time uncertainty, seconds before and after
+	window = int64(args.GetUintOrZero("window"))
+	if window > 0 && !ret.Illegal {
+		ret.Window = name.NewRankWindow(languageCode, n, birth.timestamp-window, birth.timestamp+window, loc, opts)
+	}
 
 	ctx.SetUserValue("_envelope_data", ret)
 
@@ -169,12 +234,13 @@ func nameKirsen(ctx *fasthttp.RequestCtx) {
 		familyNameRunes []rune
 		prefixName      []byte
 		prefixNameRunes []rune
-		birthTime       int64
-		longitude       float64
-		latitude        float64
-		gender          int
+		birth           *birthArgs
+		opts            name.Options
+		loc             utils.Location
+		limit           int
 		language        []byte
 		languageCode    int
+		err             error
 	)
 
 	familyName = args.Peek("family")
@@ -205,18 +271,44 @@ func nameKirsen(ctx *fasthttp.RequestCtx) {
 		}
 	}
 
-	b := args.Peek("birth")
-	if b != nil {
-		birthTime, _ = strconv.ParseInt(string(b), 10, 64)
+	loc, err = parseLocation(args)
+	if err != nil {
+		birthError(ctx, err)
+
+		return
+	}
+
+	birth, err = parseBirth(args, loc)
+	if err != nil {
+		birthError(ctx, err)
+
+		return
+	}
+
+	opts.Calendar, err = parseCalendarOptions(args, birth)
+	if err != nil {
+		birthError(ctx, err)
+
+		return
 	}
 
-	longitude = args.GetUfloatOrZero("longitude")
-	latitude = args.GetUfloatOrZero("latitude")
-	gender = args.GetUintOrZero("gender")
-	if gender != utils.GenderFemale && gender != utils.GenderMale {
-		gender = utils.GenderUnknown
+	opts.Gender = parseGender(args)
+	opts.Favourable, err = parseFavourable(args)
+	if err == nil {
+		opts.Stroke, err = parseStroke(args)
+	}
+
+	if err == nil {
+		opts.Grid, err = parseGrid(args)
+	}
+
+	if err != nil {
+		birthError(ctx, err)
+
+		return
 	}
 
+	limit = args.GetUintOrZero("limit")
 	language = args.Peek("lang")
 	languageCode = texts.AssertLanguage(string(language))
 
@@ -225,10 +317,97 @@ func nameKirsen(ctx *fasthttp.RequestCtx) {
 		ctx.RemoteIP().String(),
 		familyNameRunes,
 		prefixNameRunes,
-		birthTime,
-		latitude,
-		longitude,
+		birth.timestamp,
+		loc.Latitude,
+		loc.Longitude,
 		languageCode)
+	ret, err := name.Kirsen(languageCode, familyNameRunes, parseRunes(args.Peek("mother")), prefixNameRunes, birth.timestamp, loc, limit, opts)
+	if err != nil {
+		birthError(ctx, err)
+
+		return
+	}
+
+	for _, rank := range ret {
+		rank.Calendar.SetInputZone(birth.zone)
+	}
+
+	ctx.SetUserValue("_envelope_data", ret)
+
+	return
+}
+
+func nameAuspicious(ctx *fasthttp.RequestCtx) {
+	var (
+		args         = ctx.QueryArgs()
+		loc          utils.Location
+		from, to     int64
+		opts         name.Options
+		n            *name.Name
+		languageCode int
+		err          error
+	)
+
+	loc, err = parseLocation(args)
+	if err == nil {
+		from, err = parseInstant(args.Peek("from"), args.Peek("zone"))
+	}
+
+	if err == nil {
+		to, err = parseInstant(args.Peek("to"), args.Peek("zone"))
+	}
+
+	if err == nil {
+		opts.Calendar, err = parseCalendarOptions(args, &birthArgs{})
+	}
+
+	if err == nil {
+		opts.Favourable, err = parseFavourable(args)
+	}
+
+	if err == nil {
+		opts.Stroke, err = parseStroke(args)
+	}
+
+	if err == nil {
+		opts.Grid, err = parseGrid(args)
+	}
+
+	if err != nil {
+		birthError(ctx, err)
+
+		return
+	}
+
+	familyNameRunes := parseRunes(args.Peek("family"))
+	givenNameRunes := parseRunes(args.Peek("given"))
+	if len(familyNameRunes) > 0 && len(givenNameRunes) > 0 {
+		n = name.NewNameRunes(familyNameRunes, parseRunes(args.Peek("middle")), givenNameRunes)
+		n.Normalize()
+	}
+
+	languageCode = texts.AssertLanguage(string(args.Peek("lang")))
+
+	r := ctx.UserValue("_g").(*common.GlobalRuntime)
+	r.Logger.Printf("Auspicious time from %s between <%d> and <%d>, location <%f:%f>, name <%v.%v>, language <%d>",
+		ctx.RemoteIP().String(),
+		from,
+		to,
+		loc.Latitude,
+		loc.Longitude,
+		familyNameRunes,
+		givenNameRunes,
+		languageCode)
+	ret := name.SearchAuspicious(languageCode, from, to, loc, opts, n, args.GetUintOrZero("limit"))
+	if ret == nil {
+		ctx.SetUserValue("_envelope_code", 10403)
+		ctx.SetUserValue("_envelope_message", "Illegal name")
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+
+		return
+	}
+
+	ctx.SetUserValue("_envelope_data", ret)
 
 	return
 }
@@ -250,6 +429,12 @@ func f(c fasthttp.RequestHandler, authorization string, s *common.HTTPServer) fa
 			common.HTTPAuthorization(c, authorization), s.Runtime))
 }
 
+// Raw routes, handler writes body (not JSON) itself
+func raw(c fasthttp.RequestHandler, authorization string, s *common.HTTPServer) fasthttp.RequestHandler {
+	return common.HTTPGlobalRuntime(
+		common.HTTPAuthorization(c, authorization), s.Runtime)
+}
+
 // Logic routers
 func svc(s *common.HTTPServer) {
 	s.Router.GET("/", f(index, "none", s))
@@ -260,10 +445,26 @@ func svc(s *common.HTTPServer) {
 	s.Router.GET("/api/unihan/:mode/:input", f(apiUnihan, "none", s))
 	s.Router.GET("/api/stroke/:mode/:input", f(apiStroke, "none", s))
 	s.Router.GET("/api/traditional/:mode/:input", f(apiTraditional, "none", s))
+	s.Router.GET("/api/real_time", f(apiRealTime, "none", s))
+	s.Router.GET("/api/place/:mode/:input", f(apiPlace, "none", s))
+
+	// Calendar
+	s.Router.GET("/api/v1/calendar", f(apiCalendar, "none", s))
+	s.Router.GET("/api/v1/calendar/profiles", f(apiCalendarProfiles, "none", s))
+	s.Router.GET("/api/v1/calendar/solarterms/:year", f(apiCalendarSolarterms, "none", s))
+	s.Router.GET("/api/v1/calendar/month/:year/:month", f(apiCalendarMonth, "none", s))
+	s.Router.GET("/api/v1/calendar/ics", raw(apiCalendarICS, "none", s))
+	s.Router.GET("/api/v1/almanac/:year/:month", f(apiAlmanac, "none", s))
+	s.Router.GET("/api/v1/almanac/:year/:month/:day", f(apiAlmanac, "none", s))
+	s.Router.GET("/api/v1/bazi", f(apiBazi, "none", s))
+	s.Router.GET("/api/v1/bazi/luck", f(apiLuck, "none", s))
+	s.Router.GET("/api/v1/name/split", f(apiNameSplit, "none", s))
+	s.Router.GET("/api/v1/name/options", f(apiNameOptions, "none", s))
 
 	// Logics
 	s.Router.GET("/name/rank", f(nameRank, "none", s))
 	s.Router.GET("/name/kirsen", f(nameKirsen, "none", s))
+	s.Router.GET("/name/auspicious", f(nameAuspicious, "none", s))
 
 	return
 }
@@ -31,6 +31,7 @@
 package main
 
 import (
+	"calendar"
 	"common"
 	"dict"
 	"list"
@@ -58,6 +59,12 @@ func main() {
 	g.Config.SetDefault("Library_Path", DefaultLibraryPath)
 	g.Config.SetDefault("Default_language", DefaultLanguage)
 	texts.LanguageDefault = g.Config.GetInt("Default_language")
+	g.Config.SetDefault("Calendar_profile", calendar.ProfileStandard)
+	if _, ok := calendar.GetProfile(g.Config.GetString("Calendar_profile")); ok {
+		calendar.ProfileDefault = g.Config.GetString("Calendar_profile")
+	} else {
+		g.Logger.Printf("Unknown calendar profile <%s>, use <%s>", g.Config.GetString("Calendar_profile"), calendar.ProfileDefault)
+	}
 
 	g.Logger.Printf("Start server")
 
@@ -117,6 +124,15 @@ func main() {
 		g.Logger.Printf("Load %d lines from baijiaxing", lines)
 	}
 
+	// Gazetteer
+	lines, err = list.LoadGazetteer(g.Config.GetString("Library_Path"))
+	if err != nil {
+		// Optional, place lookups disabled
+		g.Logger.Printf("Warning: %s, place lookups disabled", err)
+	} else {
+		g.Logger.Printf("Load %d places from gazetteer", lines)
+	}
+
 	// Character dictionaries
 	lines, err = dict.LoadXinhua(g.Config.GetString("Library_Path"))
 	if err != nil {
@@ -141,6 +157,11 @@ func main() {
 		g.Logger.Printf("Load %d poetries, %d words", linePoetries, lineWords)
 	}
 
+	// Lunar table
+	if differ := calendar.CheckLunarTable(); len(differ) > 0 {
+		g.Logger.Printf("Lunar table differs from computed calendar in years %v", differ)
+	}
+
 	svc(s)
 	s.Start()
 	g.Wait()
@@ -0,0 +1,150 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file almanac.go
+ * @package calendar
+ */
+
+package calendar
+
+import (
+	"fmt"
+	"texts"
+	"utils"
+)
+
+const (
+	// DirectionNorth : 正北
+	DirectionNorth = iota
+	// DirectionNorthEast : 东北
+	DirectionNorthEast
+	// DirectionEast : 正东
+	DirectionEast
+	// DirectionSouthEast : 东南
+	DirectionSouthEast
+	// DirectionSouth : 正南
+	DirectionSouth
+	// DirectionSouthWest : 西南
+	DirectionSouthWest
+	// DirectionWest : 正西
+	DirectionWest
+	// DirectionNorthWest : 西北
+	DirectionNorthWest
+	// DirectionCenter : 中
+	DirectionCenter
+)
+
+var (
+	// XiShen by day stem
+	joyDirections = []int{
+		DirectionNorthEast, DirectionNorthWest, DirectionSouthWest, DirectionSouth, DirectionSouthEast,
+		DirectionNorthEast, DirectionNorthWest, DirectionSouthWest, DirectionSouth, DirectionSouthEast,
+	}
+
+	// CaiShen by day stem
+	wealthDirections = []int{
+		DirectionNorthEast, DirectionNorthEast, DirectionSouthWest, DirectionSouthWest, DirectionNorth,
+		DirectionNorth, DirectionEast, DirectionEast, DirectionSouth, DirectionSouth,
+	}
+
+	// FuShen by day stem
+	fortuneDirections = []int{
+		DirectionSouthEast, DirectionSouthEast, DirectionEast, DirectionEast, DirectionNorth,
+		DirectionSouth, DirectionSouthWest, DirectionSouthWest, DirectionNorthWest, DirectionWest,
+	}
+
+	// Sha by day branch % 4, ShenZiChen south, SiYouChou east, YinWuXu north, HaiMaoWei west
+	shaDirections = []int{DirectionSouth, DirectionEast, DirectionNorth, DirectionWest}
+)
+
+// Almanac : HuangLi items of day
+type Almanac struct {
+	Officer                int              `json:"officer"`
+	OfficerString          string           `json:"officer_alias"`
+	Mansion                int              `json:"mansion"`
+	MansionString          string           `json:"mansion_alias"`
+	Clash                  utils.GanzhiPair `json:"clash"`
+	ClashString            string           `json:"clash_alias"`
+	ClashAnimalString      string           `json:"clash_animal_alias"`
+	Sha                    int              `json:"sha"`
+	ShaString              string           `json:"sha_alias"`
+	ClashDescription       string           `json:"clash_description"`
+	PengZuGan              string           `json:"peng_zu_gan"`
+	PengZuZhi              string           `json:"peng_zu_zhi"`
+	FetusPosition          string           `json:"fetus_position"`
+	JoyDirection           int              `json:"joy_direction"`
+	JoyDirectionString     string           `json:"joy_direction_alias"`
+	WealthDirection        int              `json:"wealth_direction"`
+	WealthDirectionString  string           `json:"wealth_direction_alias"`
+	FortuneDirection       int              `json:"fortune_direction"`
+	FortuneDirectionString string           `json:"fortune_direction_alias"`
+}
+
+// newAlmanac : Almanac of the day pillar
+func newAlmanac(g *ganzhi, language int) *Almanac {
+	var (
+		day = g.Day
+		ret = &Almanac{
+			// JianChu : Jian on the day branch equals to month branch
+			Officer: (day.DiZhi - g.Month.DiZhi + 12) % 12,
+			// 28 mansions cycle, JiaoMuJiao on Thursday (JD 2451545 (2000-01-01) is WeiTuZhi)
+			Mansion: (g.dayNumber + 11) % 28,
+			Clash: utils.GanzhiPair{
+				TianGan: (day.TianGan + 4) % 10,
+				DiZhi:   (day.DiZhi + 6) % 12,
+			},
+			Sha:              shaDirections[day.DiZhi%4],
+			JoyDirection:     joyDirections[day.TianGan],
+			WealthDirection:  wealthDirections[day.TianGan],
+			FortuneDirection: fortuneDirections[day.TianGan],
+		}
+	)
+
+	ret.OfficerString = texts.GetAlias(texts.AliasOfficer, ret.Officer, language)
+	ret.MansionString = texts.GetAlias(texts.AliasMansion, ret.Mansion, language)
+	ret.ClashString = ret.Clash.String(language)
+	ret.ClashAnimalString = texts.GetAlias(texts.AliasAnimal, ret.Clash.DiZhi, language)
+	ret.ShaString = texts.GetAlias(texts.AliasDirection, ret.Sha, language)
+	ret.ClashDescription = fmt.Sprintf(texts.GetAlias(texts.AliasClashDescription, 0, language),
+		ret.ClashAnimalString,
+		ret.ClashString,
+		ret.ShaString)
+	ret.PengZuGan = texts.GetAlias(texts.AliasPengZuGan, day.TianGan, language)
+	ret.PengZuZhi = texts.GetAlias(texts.AliasPengZuZhi, day.DiZhi, language)
+	ret.FetusPosition = texts.GetAlias(texts.AliasFetusPosition, day.Value(), language)
+	ret.JoyDirectionString = texts.GetAlias(texts.AliasDirection, ret.JoyDirection, language)
+	ret.WealthDirectionString = texts.GetAlias(texts.AliasDirection, ret.WealthDirection, language)
+	ret.FortuneDirectionString = texts.GetAlias(texts.AliasDirection, ret.FortuneDirection, language)
+
+	return ret
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -0,0 +1,91 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file almanac_test.go
+ * @package calendar
+ */
+
+package calendar
+
+import (
+	"testing"
+	"texts"
+	"time"
+	"utils"
+)
+
+// chinaNoon : Timestamp of noon in China of the civil date
+func chinaNoon(year int, month time.Month, day int) int64 {
+	loc, _ := time.LoadLocation("Asia/Shanghai")
+
+	return time.Date(year, month, day, 12, 0, 0, 0, loc).Unix()
+}
+
+func TestAlmanacClash(t *testing.T) {
+	var cases = []struct {
+		year    int
+		month   time.Month
+		day     int
+		pillar  string
+		clash   string
+		animal  string
+		sha     string
+		officer string
+	}{
+		{1949, time.October, 1, "甲子", "戊午", "马", "正南", "平"},
+		{1984, time.February, 2, "丙寅", "庚申", "猴", "正北", "除"},
+		{2000, time.January, 1, "戊午", "壬子", "鼠", "正北", "破"},
+		{2020, time.January, 25, "丁卯", "辛酉", "鸡", "正西", "满"},
+		{2024, time.February, 10, "甲辰", "戊戌", "狗", "正南", "满"},
+	}
+
+	for _, c := range cases {
+		cal := New(chinaNoon(c.year, c.month, c.day), utils.Location{Longitude: 120})
+		a := newAlmanac(&cal.Ganzhi, texts.LanguageSimplified)
+		if s := cal.Ganzhi.Day.String(texts.LanguageSimplified); s != c.pillar {
+			t.Errorf("%d-%02d-%02d: day pillar %s, want %s", c.year, c.month, c.day, s, c.pillar)
+		}
+
+		if a.ClashString != c.clash || a.ClashAnimalString != c.animal {
+			t.Errorf("%d-%02d-%02d: clash %s%s, want %s%s", c.year, c.month, c.day, a.ClashAnimalString, a.ClashString, c.animal, c.clash)
+		}
+
+		if a.ShaString != c.sha {
+			t.Errorf("%d-%02d-%02d: sha %s, want %s", c.year, c.month, c.day, a.ShaString, c.sha)
+		}
+
+		if a.OfficerString != c.officer {
+			t.Errorf("%d-%02d-%02d: officer %s, want %s", c.year, c.month, c.day, a.OfficerString, c.officer)
+		}
+	}
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -0,0 +1,377 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file astronomy.go
+ * @package calendar
+ */
+
+package calendar
+
+import (
+	"math"
+	"time"
+)
+
+// Truncated VSOP87 heliocentric longitude of Earth (Meeus, Astronomical Algorithms, Appendix III)
+// Each term : A, B, C => A * cos(B + C * tau)
+var (
+	vsop87L0 = [][3]float64{
+		{175347046, 0, 0}, {3341656, 4.6692568, 6283.07585}, {34894, 4.6261, 12566.1517},
+		{3497, 2.7441, 5753.3849}, {3418, 2.8289, 3.5231}, {3136, 3.6277, 77713.7715},
+		{2676, 4.4181, 7860.4194}, {2343, 6.1352, 3930.2097}, {1324, 0.7425, 11506.7698},
+		{1273, 2.0371, 529.691}, {1199, 1.1096, 1577.3435}, {990, 5.233, 5884.927},
+		{902, 2.045, 26.298}, {857, 3.508, 398.149}, {780, 1.179, 5223.694},
+		{753, 2.533, 5507.553}, {505, 4.583, 18849.228}, {492, 4.205, 775.523},
+		{357, 2.92, 0.067}, {317, 5.849, 11790.629}, {284, 1.899, 796.298},
+		{271, 0.315, 10977.079}, {243, 0.345, 5486.778}, {206, 4.806, 2544.314},
+		{205, 1.869, 5573.143}, {202, 2.458, 6069.777}, {156, 0.833, 213.299},
+		{132, 3.411, 2942.463}, {126, 1.083, 20.775}, {115, 0.645, 0.98},
+		{103, 0.636, 4694.003}, {102, 0.976, 15720.839}, {102, 4.267, 7.114},
+		{99, 6.21, 2146.17}, {98, 0.68, 155.42}, {86, 5.98, 161000.69},
+		{85, 1.3, 6275.96}, {85, 3.67, 71430.7}, {80, 1.81, 17260.15},
+		{79, 3.04, 12036.46}, {75, 1.76, 5088.63}, {74, 3.5, 3154.69},
+		{74, 4.68, 801.82}, {70, 0.83, 9437.76}, {62, 3.98, 8827.39},
+		{61, 1.82, 7084.9}, {57, 2.78, 6286.6}, {56, 4.39, 14143.5},
+		{56, 3.47, 6279.55}, {52, 0.19, 12139.55}, {52, 1.33, 1748.02},
+		{51, 0.28, 5856.48}, {49, 0.49, 1194.45}, {41, 5.37, 8429.24},
+		{41, 2.4, 19651.05}, {39, 6.17, 10447.39}, {37, 6.04, 10213.29},
+		{37, 2.57, 1059.38}, {36, 1.71, 2352.87}, {36, 1.78, 6812.77},
+		{33, 0.59, 17789.85}, {30, 0.44, 83996.85}, {30, 2.74, 1349.87},
+		{25, 3.16, 4690.48},
+	}
+	vsop87L1 = [][3]float64{
+		{628331966747, 0, 0}, {206059, 2.678235, 6283.07585}, {4303, 2.6351, 12566.1517},
+		{425, 1.59, 3.523}, {119, 5.796, 26.298}, {109, 2.966, 1577.344},
+		{93, 2.59, 18849.23}, {72, 1.14, 529.69}, {68, 1.87, 398.15},
+		{67, 4.41, 5507.55}, {59, 2.89, 5223.69}, {56, 2.17, 155.42},
+		{45, 0.4, 796.3}, {36, 0.47, 775.52}, {29, 2.65, 7.11},
+		{21, 5.34, 0.98}, {19, 1.85, 5486.78}, {19, 4.97, 213.3},
+		{17, 2.99, 6275.96}, {16, 0.03, 2544.31}, {16, 1.43, 2146.17},
+		{15, 1.21, 10977.08}, {12, 2.83, 1748.02}, {12, 3.26, 5088.63},
+		{12, 5.27, 1194.45}, {12, 2.08, 4694}, {11, 0.77, 553.57},
+		{10, 1.3, 6286.6}, {10, 4.24, 1349.87}, {9, 2.7, 242.73},
+		{9, 5.64, 951.72}, {8, 5.3, 2352.87}, {6, 2.65, 9437.76},
+		{6, 4.67, 4690.48},
+	}
+	vsop87L2 = [][3]float64{
+		{52919, 0, 0}, {8720, 1.0721, 6283.0758}, {309, 0.867, 12566.152},
+		{27, 0.05, 3.52}, {16, 5.19, 26.3}, {16, 3.68, 155.42},
+		{10, 0.76, 18849.23}, {9, 2.06, 77713.77}, {7, 0.83, 775.52},
+		{5, 4.66, 1577.34}, {4, 1.03, 7.11}, {4, 3.44, 5573.14},
+		{3, 5.14, 796.3}, {3, 6.05, 5507.55}, {3, 1.19, 242.73},
+		{3, 6.12, 529.69}, {3, 0.31, 398.15}, {3, 2.28, 553.57},
+		{2, 4.38, 5223.69}, {2, 3.75, 0.98},
+	}
+	vsop87L3 = [][3]float64{
+		{289, 5.844, 6283.076}, {35, 0, 0}, {17, 5.49, 12566.15},
+		{3, 5.2, 155.42}, {1, 4.72, 3.52}, {1, 5.3, 18849.23},
+		{1, 5.97, 242.73},
+	}
+	vsop87L4 = [][3]float64{
+		{114, 3.142, 0}, {8, 4.13, 6283.08}, {1, 3.84, 12566.15},
+	}
+	vsop87L5 = [][3]float64{
+		{1, 3.14, 0},
+	}
+)
+
+const (
+	// J2000 : Julian day of 2000-01-01 12:00 TT
+	j2000 = 2451545.0
+	// Julian day of unix epoch
+	jdUnixEpoch = 2440587.5
+	// Days of tropical year
+	tropicalYear = 365.2421896698
+	// Arc seconds to degrees
+	arcSecond = 1.0 / 3600.0
+)
+
+func normalizeDegrees(d float64) float64 {
+	d = math.Mod(d, 360)
+	if d < 0 {
+		d += 360
+	}
+
+	return d
+}
+
+func radians(d float64) float64 {
+	return d * math.Pi / 180
+}
+
+// julianDay : Julian day (UT) of time
+func julianDay(t time.Time) float64 {
+	return float64(t.Unix())/86400 + float64(t.Nanosecond())/86400e9 + jdUnixEpoch
+}
+
+// julianDayTime : Time of Julian day (UT)
+func julianDayTime(jd float64) time.Time {
+	ms := int64(math.Round((jd - jdUnixEpoch) * 86400000))
+	sec := ms / 1000
+	if ms%1000 < 0 {
+		sec--
+	}
+
+	return time.Unix(sec, (ms-sec*1000)*int64(time.Millisecond)).UTC()
+}
+
+// deltaT : TT - UT in seconds, polynomials of Espenak & Meeus
+func deltaT(year float64) float64 {
+	var t float64
+
+	switch {
+	case year < 1700:
+		u := (year - 1820) / 100
+		return -20 + 32*u*u
+	case year < 1800:
+		t = year - 1700
+		return 8.83 + 0.1603*t - 0.0059285*t*t + 0.00013336*t*t*t - t*t*t*t/1174000
+	case year < 1860:
+		t = year - 1800
+		return 13.72 - 0.332447*t + 0.0068612*t*t + 0.0041116*math.Pow(t, 3) -
+			0.00037436*math.Pow(t, 4) + 0.0000121272*math.Pow(t, 5) -
+			0.0000001699*math.Pow(t, 6) + 0.000000000875*math.Pow(t, 7)
+	case year < 1900:
+		t = year - 1860
+		return 7.62 + 0.5737*t - 0.251754*t*t + 0.01680668*math.Pow(t, 3) -
+			0.0004473624*math.Pow(t, 4) + math.Pow(t, 5)/233174
+	case year < 1920:
+		t = year - 1900
+		return -2.79 + 1.494119*t - 0.0598939*t*t + 0.0061966*math.Pow(t, 3) - 0.000197*math.Pow(t, 4)
+	case year < 1941:
+		t = year - 1920
+		return 21.20 + 0.84493*t - 0.0761*t*t + 0.0020936*math.Pow(t, 3)
+	case year < 1961:
+		t = year - 1950
+		return 29.07 + 0.407*t - t*t/233 + math.Pow(t, 3)/2547
+	case year < 1986:
+		t = year - 1975
+		return 45.45 + 1.067*t - t*t/260 - math.Pow(t, 3)/718
+	case year < 2005:
+		t = year - 2000
+		return 63.86 + 0.3345*t - 0.060374*t*t + 0.0017275*math.Pow(t, 3) +
+			0.000651814*math.Pow(t, 4) + 0.00002373599*math.Pow(t, 5)
+	case year < 2050:
+		t = year - 2000
+		return 62.92 + 0.32217*t + 0.005589*t*t
+	case year < 2150:
+		u := (year - 1820) / 100
+		return -20 + 32*u*u - 0.5628*(2150-year)
+	default:
+		u := (year - 1820) / 100
+		return -20 + 32*u*u
+	}
+}
+
+func vsop87Sum(terms [][3]float64, tau float64) float64 {
+	var ret float64
+
+	for _, term := range terms {
+		ret += term[0] * math.Cos(term[1]+term[2]*tau)
+	}
+
+	return ret
+}
+
+// earthLongitude : Heliocentric ecliptic longitude of Earth in degrees (FK5 not applied)
+func earthLongitude(jde float64) float64 {
+	tau := (jde - j2000) / 365250
+	l := vsop87Sum(vsop87L0, tau) +
+		vsop87Sum(vsop87L1, tau)*tau +
+		vsop87Sum(vsop87L2, tau)*tau*tau +
+		vsop87Sum(vsop87L3, tau)*math.Pow(tau, 3) +
+		vsop87Sum(vsop87L4, tau)*math.Pow(tau, 4) +
+		vsop87Sum(vsop87L5, tau)*math.Pow(tau, 5)
+
+	return normalizeDegrees(l / 1e8 * 180 / math.Pi)
+}
+
+// nutation : Nutation in longitude and obliquity in degrees
+func nutation(jde float64) (float64, float64) {
+	var (
+		t     = (jde - j2000) / 36525
+		omega = radians(125.04452 - 1934.136261*t)
+		ls    = radians(280.4665 + 36000.7698*t)
+		lm    = radians(218.3165 + 481267.8813*t)
+	)
+
+	dPsi := -17.20*math.Sin(omega) - 1.32*math.Sin(2*ls) - 0.23*math.Sin(2*lm) + 0.21*math.Sin(2*omega)
+	dEpsilon := 9.20*math.Cos(omega) + 0.57*math.Cos(2*ls) + 0.10*math.Cos(2*lm) - 0.09*math.Cos(2*omega)
+
+	return dPsi * arcSecond, dEpsilon * arcSecond
+}
+
+// sunDistance : Radius vector of Earth in AU (low precision)
+func sunDistance(jde float64) float64 {
+	var (
+		t = (jde - j2000) / 36525
+		m = radians(357.52911 + 35999.05029*t - 0.0001537*t*t)
+		e = 0.016708634 - 0.000042037*t - 0.0000001267*t*t
+		c = (1.914602-0.004817*t-0.000014*t*t)*math.Sin(m) +
+			(0.019993-0.000101*t)*math.Sin(2*m) +
+			0.000289*math.Sin(3*m)
+		v = m + radians(c)
+	)
+
+	return 1.000001018 * (1 - e*e) / (1 + e*math.Cos(v))
+}
+
+// sunApparentLongitude : Apparent geocentric longitude of the sun in degrees
+func sunApparentLongitude(jde float64) float64 {
+	var (
+		theta   = earthLongitude(jde) + 180
+		dPsi, _ = nutation(jde)
+	)
+
+	// FK5, nutation, aberration
+	theta += -0.09033*arcSecond + dPsi - 20.4898*arcSecond/sunDistance(jde)
+
+	return normalizeDegrees(theta)
+}
+
+// sunLongitudeTime : Time (UT) when the apparent longitude of the sun reaches given degrees, near given Julian day
+func sunLongitudeTime(longitude, jdGuess float64) time.Time {
+	var (
+		jde = jdGuess
+		d   float64
+	)
+
+	for i := 0; i < 20; i++ {
+		d = longitude - sunApparentLongitude(jde)
+		d = math.Mod(d+540, 360) - 180
+		jde += d * tropicalYear / 360
+		if math.Abs(d) < 1e-7 {
+			break
+		}
+	}
+
+	year := 2000 + (jde-j2000)/tropicalYear
+
+	return julianDayTime(jde - deltaT(year)/86400)
+}
+
+// computeSolarterms : Compute 24 solarterms (XiaoHan to DongZhi) of given year by solar longitude
+func computeSolarterms(year int) []time.Time {
+	var (
+		ret   = make([]time.Time, 24)
+		start = julianDay(time.Date(year, 1, 6, 0, 0, 0, 0, time.UTC))
+	)
+
+	for s := 0; s < 24; s++ {
+		ret[s] = sunLongitudeTime(normalizeDegrees(285+float64(s)*15), start+float64(s)*tropicalYear/24)
+	}
+
+	return ret
+}
+
+// equationOfTime : Apparent minus mean solar time in seconds of Julian day (UT) (Meeus, chapter 28)
+func equationOfTime(jd float64) float64 {
+	var (
+		year           = 2000 + (jd-j2000)/tropicalYear
+		jde            = jd + deltaT(year)/86400
+		t              = (jde - j2000) / 36525
+		tau            = t / 10
+		dPsi, dEpsilon = nutation(jde)
+		lambda         = radians(sunApparentLongitude(jde))
+		epsilon        = radians(23.4392911 - 46.8150*arcSecond*t - 0.00059*arcSecond*t*t + 0.001813*arcSecond*t*t*t + dEpsilon)
+		l0, alpha, e   float64
+	)
+
+	// Mean longitude of the sun
+	l0 = 280.4664567 + 360007.6982779*tau + 0.03032028*tau*tau + tau*tau*tau/49931 -
+		tau*tau*tau*tau/15300 - tau*tau*tau*tau*tau/2000000
+
+	// Apparent right ascension
+	alpha = math.Atan2(math.Cos(epsilon)*math.Sin(lambda), math.Cos(lambda)) * 180 / math.Pi
+
+	e = l0 - 0.0057183 - alpha + dPsi*math.Cos(epsilon)
+	e = normalizeDegrees(e+180) - 180
+
+	// 1 degree = 4 minutes
+	return e * 240
+}
+
+// newMoonCorrections : Periodic terms of new moon, coefficient and multiples of E, M, M', F, Omega
+var newMoonCorrections = [][6]float64{
+	{-0.40720, 0, 0, 1, 0, 0}, {0.17241, 1, 1, 0, 0, 0}, {0.01608, 0, 0, 2, 0, 0},
+	{0.01039, 0, 0, 0, 2, 0}, {0.00739, 1, -1, 1, 0, 0}, {-0.00514, 1, 1, 1, 0, 0},
+	{0.00208, 2, 2, 0, 0, 0}, {-0.00111, 0, 0, 1, -2, 0}, {-0.00057, 0, 0, 1, 2, 0},
+	{0.00056, 1, 1, 2, 0, 0}, {-0.00042, 0, 0, 3, 0, 0}, {0.00042, 1, 1, 0, 2, 0},
+	{0.00038, 1, 1, 0, -2, 0}, {-0.00024, 1, -1, 2, 0, 0}, {-0.00017, 0, 0, 0, 0, 1},
+	{-0.00007, 0, 2, 1, 0, 0}, {0.00004, 0, 0, 2, -2, 0}, {0.00004, 0, 3, 0, 0, 0},
+	{0.00003, 0, 1, 1, -2, 0}, {0.00003, 0, 0, 2, 2, 0}, {-0.00003, 0, 1, 1, 2, 0},
+	{0.00003, 0, -1, 1, 2, 0}, {-0.00002, 0, -1, 1, -2, 0}, {-0.00002, 0, 1, 3, 0, 0},
+	{0.00002, 0, 0, 4, 0, 0},
+}
+
+// newMoonPlanetary : Additional corrections of new moon, coefficient and A = B + C * k
+var newMoonPlanetary = [][3]float64{
+	{0.000325, 299.77, 0.107408}, {0.000165, 251.88, 0.016321}, {0.000164, 251.83, 26.651886},
+	{0.000126, 349.42, 36.412478}, {0.00011, 84.66, 18.206239}, {0.000062, 141.74, 53.303771},
+	{0.00006, 207.14, 2.453732}, {0.000056, 154.84, 7.30686}, {0.000047, 34.52, 27.261239},
+	{0.000042, 207.19, 0.121824}, {0.00004, 291.34, 1.844379}, {0.000037, 161.72, 24.198154},
+	{0.000035, 239.56, 25.513099}, {0.000023, 331.55, 3.592518},
+}
+
+// newMoon : Julian day (UT) of the k-th new moon since 2000-01-06 (Meeus, chapter 49)
+func newMoon(k int) float64 {
+	var (
+		fk  = float64(k)
+		t   = fk / 1236.85
+		jde = 2451550.09766 + 29.530588861*fk + 0.00015437*t*t - 0.00000015*t*t*t + 0.00000000073*t*t*t*t
+		e   = 1 - 0.002516*t - 0.0000074*t*t
+		m   = radians(2.5534 + 29.1053567*fk - 0.0000014*t*t - 0.00000011*t*t*t)
+		mm  = radians(201.5643 + 385.81693528*fk + 0.0107582*t*t + 0.00001238*t*t*t - 0.000000058*t*t*t*t)
+		f   = radians(160.7108 + 390.67050284*fk - 0.0016118*t*t - 0.00000227*t*t*t + 0.000000011*t*t*t*t)
+		o   = radians(124.7746 - 1.56375588*fk + 0.0020672*t*t + 0.00000215*t*t*t)
+	)
+
+	for _, c := range newMoonCorrections {
+		jde += c[0] * math.Pow(e, c[1]) * math.Sin(c[2]*m+c[3]*mm+c[4]*f+c[5]*o)
+	}
+
+	// A1 has a T^2 term
+	jde += newMoonPlanetary[0][0] * math.Sin(radians(newMoonPlanetary[0][1]+newMoonPlanetary[0][2]*fk-0.009173*t*t))
+	for _, c := range newMoonPlanetary[1:] {
+		jde += c[0] * math.Sin(radians(c[1]+c[2]*fk))
+	}
+
+	year := 2000 + (jde-j2000)/tropicalYear
+
+	return jde - deltaT(year)/86400
+}
+
+// newMoonIndex : Approximate index of new moon of Julian day
+func newMoonIndex(jd float64) int {
+	return int(math.Floor((jd - 2451550.09766) / 29.530588861))
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -31,6 +31,7 @@
 package calendar
 
 import (
+	"math"
 	"time"
 	"utils"
 )
@@ -56,10 +57,27 @@ func (s *timeSpec) parse(t time.Time) {
 	return
 }
 
+// Options : Options of calendar calculation
+type Options struct {
+	// Birth hour unknown, only year, month and day pillars
+	HourUnknown bool `json:"hour_unknown"`
+	// Convention profile, default profile if name is empty
+	Profile Profile `json:"profile"`
+}
+
+// normalize : Fill default profile
+func (opts *Options) normalize() {
+	if opts.Profile.Name == "" {
+		opts.Profile, _ = GetProfile("")
+	}
+}
+
 // Calendar : Main struct
 type Calendar struct {
 	t           time.Time
+	Timestamp   int64          `json:"timestamp"`
 	Location    utils.Location `json:"location"`
+	InputTime   *timeSpec      `json:"input_time,omitempty"`
 	GeneralTime timeSpec       `json:"general_time"`
 	UTCTime     timeSpec       `json:"utc_time"`
 	ChinaTime   timeSpec       `json:"china_time"`
@@ -68,18 +86,28 @@ type Calendar struct {
 	Solar       solar          `json:"solar"`
 	Lunar       lunar          `json:"lunar"`
 	Ganzhi      ganzhi         `json:"ganzhi"`
+	Options     Options        `json:"options"`
 }
 
 // New : Create new calendar
 func New(timestamp int64, loc utils.Location) *Calendar {
+	return NewWithOptions(timestamp, loc, Options{})
+}
+
+// NewWithOptions : Create new calendar with options
+func NewWithOptions(timestamp int64, loc utils.Location, opts Options) *Calendar {
+	opts.normalize()
 	ret := &Calendar{
-		t:        time.Unix(timestamp, 0),
-		Location: loc,
+		t:         time.Unix(timestamp, 0),
+		Timestamp: timestamp,
+		Location:  loc,
+		Options:   opts,
 	}
 
 	var (
-		tChina = ret.China()
-		tReal  = ret.Real()
+		tChina  = ret.China()
+		tReal   = ret.Real()
+		tGanzhi = ganzhiTime(ret.t, loc, opts.Profile)
 	)
 
 	ret.GeneralTime.parse(ret.General())
@@ -90,15 +118,29 @@ func New(timestamp int64, loc utils.Location) *Calendar {
 
 	ret.Solar = solar{t: &tChina}
 	ret.Lunar = lunar{t: &tChina}
-	ret.Ganzhi = ganzhi{t: &tReal}
+	ret.Ganzhi = ganzhi{t: &tGanzhi, hourUnknown: opts.HourUnknown, ziSplit: opts.Profile.ZiSplit}
 
 	ret.Solar.complete()
 	ret.Lunar.complete()
 	ret.Ganzhi.complete()
+	if opts.Profile.AnimalByLiChun {
+		ret.Lunar.AnimalSign = ret.Ganzhi.Year.DiZhi
+		ret.Lunar.AnimalSignString = animalSignAliases[ret.Lunar.AnimalSign]
+	}
 
 	return ret
 }
 
+// SetInputZone : Show wall clock of the time zone birth time input with
+func (c *Calendar) SetInputZone(zone *time.Location) {
+	if zone != nil {
+		c.InputTime = &timeSpec{}
+		c.InputTime.parse(c.t.In(zone))
+	}
+
+	return
+}
+
 // General : Genaral time
 func (c *Calendar) General() time.Time {
 	return c.t
@@ -122,114 +164,107 @@ func (c *Calendar) Local() time.Time {
 	return c.t.In(l)
 }
 
-var (
-	realSunFixLeap = []int{
-		0,
-		-189, -218, -246, -273, -301, -327, -354, -380, -405, -430,
-		-455, -479, -502, -525, -547, -568, -589, -609, -628, -647,
-		-665, -682, -698, -714, -728, -742, -755, -779, -790, -799,
-		-817, -824, -830, -836, -841, -845, -849, -851, -853, -854,
-		-855, -854, -853, -851, -848, -845, -841, -836, -831, -824,
-		-818, -810, -802, -793, -664, -774, -763, -752, -741, -728,
-		-716, -703, -689, -675, -661, -647, -632, -616, -601, -585,
-		-568, -552, -535, -518, -501, -484, -466, -449, -431, -413,
-		-395, -377, -358, -340, -322, -304, -285, -267, -249, -231,
-		-213, -196, -178, -161, -144, -127, -110, -93, -77, -61,
-		46, 30, 16, 1, 13, 27, 41, 54, 66, 79,
-		91, 102, 113, 124, 134, 143, 153, 161, 169, 177,
-		184, 70, 196, 201, 206, 210, 217, 216, 219, 220,
-		222, 222, 222, 222, 221, 219, 217, 214, 211, 207,
-		203, 198, 193, 187, 181, 174, 167, 159, 151, 142,
-		133, 124, 114, 104, 94, 83, 72, 60, 48, 36,
-		24, 12, 1, 14, 39, 52, -65, -78, -91, -105,
-		-117, -130, -143, -156, -168, -181, -193, -205, -217, -229,
-		-240, -251, -262, -273, -283, -293, -302, -311, -320, -328,
-		-336, -343, -350, -356, -362, -368, -372, -376, -380, -383,
-		-385, -387, -389, -389, -389, -389, -388, -386, -384, -381,
-		-377, -373, -368, -363, -357, -351, -344, -336, -328, -319,
-		-310, -300, -290, -279, -267, -255, -242, -229, -216, -201,
-		-187, -171, -156, -140, -123, -107, -89, -72, 54, 35,
-		17, 2, 21, 41, 60, 80, 100, 121, 141, 162,
-		183, 183, 204, 225, 246, 267, 288, 310, 331, 353,
-		374, 395, 417, 438, 459, 480, 501, 522, 542, 562,
-		582, 602, 621, 640, 659, 678, 696, 696, 713, 731,
-		748, 764, 780, 796, 796, 811, 825, 839, 853, 866,
-		878, 890, 901, 912, 921, 931, 940, 948, 955, 961,
-		967, 972, 976, 980, 982, 984, 985, 985, 984, 983,
-		981, 977, 973, 969, 963, 956, 949, 941, 932, 922,
-		911, 900, 887, 874, 860, 846, 830, 814, 797, 779,
-		760, 741, 721, 700, 678, 656, 633, 609, 585, 561,
-		535, 509, 483, 456, 429, 402, 374, 346, 317, 288,
-		259, 230, 201, 171, 142, 112, 82, 52, 23, 7,
-		37, -66, -96, -125, -154, -183,
+// realSunOffset : Offset of real-sun time from UTC in seconds
+func realSunOffset(t time.Time, loc utils.Location) int {
+	return int(loc.Longitude*240) + int(math.Round(equationOfTime(julianDay(t))))
+}
+
+// RealSun : Details of real-sun time correction
+type RealSun struct {
+	Timestamp       int64          `json:"timestamp"`
+	Location        utils.Location `json:"location"`
+	InputTime       *timeSpec      `json:"input_time,omitempty"`
+	ChinaTime       timeSpec       `json:"china_time"`
+	MeanTime        timeSpec       `json:"mean_time"`
+	RealTime        timeSpec       `json:"real_time"`
+	LongitudeOffset int            `json:"longitude_offset"`
+	EquationOfTime  int            `json:"equation_of_time"`
+	Correction      int            `json:"correction"`
+}
+
+// NewRealSun : Real-sun time correction of timestamp at location
+func NewRealSun(timestamp int64, loc utils.Location) *RealSun {
+	var (
+		c   = New(timestamp, loc)
+		ret = &RealSun{
+			Timestamp:       timestamp,
+			Location:        loc,
+			ChinaTime:       c.ChinaTime,
+			MeanTime:        c.LocalTime,
+			RealTime:        c.RealTime,
+			LongitudeOffset: c.LocalTime.Offset,
+		}
+	)
+
+	ret.EquationOfTime = c.RealTime.Offset - c.LocalTime.Offset
+	// Real-sun time minus China clock time
+	ret.Correction = c.RealTime.Offset - c.ChinaTime.Offset
+
+	return ret
+}
+
+// SetInputZone : Show wall clock of the time zone birth time input with
+func (r *RealSun) SetInputZone(zone *time.Location) {
+	if zone != nil {
+		r.InputTime = &timeSpec{}
+		r.InputTime.parse(time.Unix(r.Timestamp, 0).In(zone))
 	}
-	realSunFixNormal = []int{
-		0,
-		-189, -218, -246, -273, -301, -327, -354, -380, -405, -430,
-		-455, -479, -502, -525, -547, -568, -589, -609, -628, -647,
-		-665, -682, -698, -714, -728, -742, -755, -779, -790, -799,
-		-817, -824, -830, -836, -841, -845, -849, -851, -853, -854,
-		-855, -854, -853, -851, -848, -845, -841, -836, -831, -824,
-		-818, -810, -802, -793, -664, -774, -763, -752, -741,
-		-716, -703, -689, -675, -661, -647, -632, -616, -601, -585,
-		-568, -552, -535, -518, -501, -484, -466, -449, -431, -413,
-		-395, -377, -358, -340, -322, -304, -285, -267, -249, -231,
-		-213, -196, -178, -161, -144, -127, -110, -93, -77, -61,
-		46, 30, 16, 1, 13, 27, 41, 54, 66, 79,
-		91, 102, 113, 124, 134, 143, 153, 161, 169, 177,
-		184, 70, 196, 201, 206, 210, 217, 216, 219, 220,
-		222, 222, 222, 222, 221, 219, 217, 214, 211, 207,
-		203, 198, 193, 187, 181, 174, 167, 159, 151, 142,
-		133, 124, 114, 104, 94, 83, 72, 60, 48, 36,
-		24, 12, 1, 14, 39, 52, -65, -78, -91, -105,
-		-117, -130, -143, -156, -168, -181, -193, -205, -217, -229,
-		-240, -251, -262, -273, -283, -293, -302, -311, -320, -328,
-		-336, -343, -350, -356, -362, -368, -372, -376, -380, -383,
-		-385, -387, -389, -389, -389, -389, -388, -386, -384, -381,
-		-377, -373, -368, -363, -357, -351, -344, -336, -328, -319,
-		-310, -300, -290, -279, -267, -255, -242, -229, -216, -201,
-		-187, -171, -156, -140, -123, -107, -89, -72, 54, 35,
-		17, 2, 21, 41, 60, 80, 100, 121, 141, 162,
-		183, 183, 204, 225, 246, 267, 288, 310, 331, 353,
-		374, 395, 417, 438, 459, 480, 501, 522, 542, 562,
-		582, 602, 621, 640, 659, 678, 696, 696, 713, 731,
-		748, 764, 780, 796, 796, 811, 825, 839, 853, 866,
-		878, 890, 901, 912, 921, 931, 940, 948, 955, 961,
-		967, 972, 976, 980, 982, 984, 985, 985, 984, 983,
-		981, 977, 973, 969, 963, 956, 949, 941, 932, 922,
-		911, 900, 887, 874, 860, 846, 830, 814, 797, 779,
-		760, 741, 721, 700, 678, 656, 633, 609, 585, 561,
-		535, 509, 483, 456, 429, 402, 374, 346, 317, 288,
-		259, 230, 201, 171, 142, 112, 82, 52, 23, 7,
-		37, -66, -96, -125, -154, -183,
+
+	return
+}
+
+// ganzhiTime : Time which ganzhi calculated by, in solar time of profile
+func ganzhiTime(t time.Time, loc utils.Location, p Profile) time.Time {
+	var l *time.Location
+
+	switch p.SolarTime {
+	case SolarTimeMean:
+		l = time.FixedZone("LocalTime", int(loc.Longitude*240))
+	case SolarTimeClock:
+		l, _ = time.LoadLocation("Asia/Shanghai")
+	default:
+		l = time.FixedZone("RealSunTime", realSunOffset(t, loc))
 	}
-)
+
+	return t.In(l)
+}
 
 // Real : Real-sun time
 func (c *Calendar) Real() time.Time {
-	// Find index
-	leap := false
-	y := c.t.Year()
-	if y%400 == 0 {
-		// Leap
-		leap = true
-	} else if y%4 == 0 && y%100 != 0 {
-		// Leap
-		leap = true
-	}
+	l := time.FixedZone("RealSunTime", realSunOffset(c.t, c.Location))
+
+	return c.t.In(l)
+}
+
+// RealSunTimestamp : Timestamp of given wall clock in real-sun time of location
+func RealSunTimestamp(year int, month time.Month, day, hour, minute, second int, loc utils.Location) int64 {
+	var (
+		wall = time.Date(year, month, day, hour, minute, second, 0, time.UTC)
+		t    = wall
+	)
 
-	d := c.t.YearDay()
-	fix := 0
-	if leap {
-		fix = realSunFixLeap[d]
-	} else {
-		fix = realSunFixNormal[d]
+	// Offset varies with date, converge
+	for i := 0; i < 3; i++ {
+		t = wall.Add(-time.Duration(realSunOffset(t, loc)) * time.Second)
 	}
 
-	offset := int(c.Location.Longitude*240) + fix
-	l := time.FixedZone("RealSunTime", offset)
+	return t.Unix()
+}
 
-	return c.t.In(l)
+// SolarTimeTimestamp : Timestamp of given wall clock in solar time of location, SolarTimeReal / SolarTimeMean / SolarTimeClock
+func SolarTimeTimestamp(year int, month time.Month, day, hour, minute, second int, loc utils.Location, solarTime int) int64 {
+	switch solarTime {
+	case SolarTimeMean:
+		l := time.FixedZone("LocalTime", int(loc.Longitude*240))
+
+		return time.Date(year, month, day, hour, minute, second, 0, l).Unix()
+	case SolarTimeClock:
+		l, _ := time.LoadLocation("Asia/Shanghai")
+
+		return time.Date(year, month, day, hour, minute, second, 0, l).Unix()
+	}
+
+	return RealSunTimestamp(year, month, day, hour, minute, second, loc)
 }
 
 /*
@@ -37,6 +37,9 @@ import (
 
 type ganzhi struct {
 	t           *time.Time
+	hourUnknown bool
+	ziSplit     bool
+	dayNumber   int
 	YearOrder   int              `json:"year_order"`
 	Year        utils.GanzhiPair `json:"year"`
 	YearString  string           `json:"year_alias"`
@@ -46,6 +49,7 @@ type ganzhi struct {
 	DayString   string           `json:"day_alias"`
 	Hour        utils.GanzhiPair `json:"hour"`
 	HourString  string           `json:"hour_alias"`
+	Uncertain   []string         `json:"uncertain,omitempty"`
 }
 
 // GetSolartermsGanzhi : Get solarterms from LiChun to DaHan for a whole ganzhi year
@@ -78,8 +82,8 @@ func (g *ganzhi) complete() {
 	g.YearOrder = year
 	solarterms = GetSolartermsGanzhi(year)
 
-	g.Year.TianGan = (year - 4) % 10
-	g.Year.DiZhi = (year - 4) % 12
+	g.Year.TianGan = utils.Mod(year-4, 10)
+	g.Year.DiZhi = utils.Mod(year-4, 12)
 	//g.YearString = g.Year.String()
 
 	for idx, solarterm = range solarterms {
@@ -90,8 +94,8 @@ func (g *ganzhi) complete() {
 	}
 
 	month = (idx / 2) + 1
-	g.Month.TianGan = (month + g.Year.TianGan*2 + 1) % 10
-	g.Month.DiZhi = (month + 1) % 12
+	g.Month.TianGan = utils.Mod(month+g.Year.TianGan*2+1, 10)
+	g.Month.DiZhi = utils.Mod(month+1, 12)
 	//g.MonthString = g.Month.String()
 
 	year = g.t.Year()
@@ -107,21 +111,164 @@ func (g *ganzhi) complete() {
 		dI = 6
 	}
 	dH = g.t.Hour()
-	if dH >= 23 {
+	if dH >= 23 && !g.hourUnknown && !g.ziSplit {
 		dD++
 	}
 
-	g.Day.TianGan = ((4 * dC) + (dC / 4) + (5 * dY) + (dY / 4) + ((3 * (dM + 1)) / 5) + dD - 4) % 10
-	g.Day.DiZhi = ((8 * dC) + (dC / 4) + (5 * dY) + (dY / 4) + ((3 * (dM + 1)) / 5) + dD + 6 + dI) % 12
+	// Julian day number of the date of day pillar
+	g.dayNumber = int(julianDay(time.Date(g.t.Year(), g.t.Month(), dD, 12, 0, 0, 0, time.UTC)))
+
+	g.Day.TianGan = utils.Mod((4*dC)+(dC/4)+(5*dY)+(dY/4)+((3*(dM+1))/5)+dD-4, 10)
+	g.Day.DiZhi = utils.Mod((8*dC)+(dC/4)+(5*dY)+(dY/4)+((3*(dM+1))/5)+dD+6+dI, 12)
 	//g.DayString = g.Day.String()
 
+	if g.hourUnknown {
+		// Three pillars only
+		g.Hour.TianGan = -1
+		g.Hour.DiZhi = -1
+		g.Uncertain = g.uncertainPillars()
+
+		return
+	}
+
 	g.Hour.DiZhi = ((g.t.Hour() + 1) / 2) % 12
 	g.Hour.TianGan = (g.Hour.DiZhi + g.Day.TianGan*2) % 10
+	if dH >= 23 && g.ziSplit {
+		// WanZiShi, stem follows the next day
+		g.Hour.TianGan = (g.Day.TianGan + 1) * 2 % 10
+	}
 	//g.HourString = g.Hour.String()
 
 	return
 }
 
+// uncertainPillars : Pillars may change within the day of unknown hour, if a Jie (LiChun, JingZhe ...) falls on it,
+// and the day pillar if it changes at 23:00 (not ZiSplit)
+func (g *ganzhi) uncertainPillars() []string {
+	var (
+		start = time.Date(g.t.Year(), g.t.Month(), g.t.Day(), 0, 0, 0, 0, g.t.Location())
+		end   = start.AddDate(0, 0, 1)
+		ret   []string
+	)
+
+	for idx, solarterm := range GetSolarterms(g.t.Year()) {
+		if idx%2 != 0 || solarterm.Before(start) || !solarterm.Before(end) {
+			continue
+		}
+
+		if idx == 2 {
+			// LiChun
+			ret = append(ret, "year")
+		}
+
+		ret = append(ret, "month")
+	}
+
+	if !g.ziSplit {
+		// Born in 23:00 - 24:00 is of the next day
+		ret = append(ret, "day")
+	}
+
+	return ret
+}
+
+const (
+	// PillarWindowMax : Max time window of pillar segments in seconds
+	PillarWindowMax = 86400 * 2
+
+	// Scan step of pillar segments in seconds
+	pillarScanStep = 60
+)
+
+// PillarSegment : Sub-interval [From, To) of time window with the same pillars
+type PillarSegment struct {
+	From  int64            `json:"from"`
+	To    int64            `json:"to"`
+	Year  utils.GanzhiPair `json:"year"`
+	Month utils.GanzhiPair `json:"month"`
+	Day   utils.GanzhiPair `json:"day"`
+	Hour  utils.GanzhiPair `json:"hour"`
+}
+
+// pillarsAt : Ganzhi of timestamp, in solar time of profile
+func pillarsAt(timestamp int64, loc utils.Location, opts Options) *ganzhi {
+	opts.normalize()
+	var (
+		tg  = ganzhiTime(time.Unix(timestamp, 0), loc, opts.Profile)
+		ret = &ganzhi{t: &tg, hourUnknown: opts.HourUnknown, ziSplit: opts.Profile.ZiSplit}
+	)
+
+	ret.complete()
+
+	return ret
+}
+
+func (g *ganzhi) samePillars(o *ganzhi) bool {
+	return g.Year == o.Year && g.Month == o.Month && g.Day == o.Day && g.Hour == o.Hour
+}
+
+// PillarSegments : Split time window [from, to] into segments of distinct pillars
+func PillarSegments(from, to int64, loc utils.Location, opts Options) []*PillarSegment {
+	var (
+		ret  []*PillarSegment
+		curr *ganzhi
+		seg  *PillarSegment
+	)
+
+	if to < from {
+		from, to = to, from
+	}
+
+	if to-from > PillarWindowMax {
+		to = from + PillarWindowMax
+	}
+
+	_new := func(ts int64, g *ganzhi) *PillarSegment {
+		return &PillarSegment{
+			From:  ts,
+			To:    to,
+			Year:  g.Year,
+			Month: g.Month,
+			Day:   g.Day,
+			Hour:  g.Hour,
+		}
+	}
+
+	curr = pillarsAt(from, loc, opts)
+	seg = _new(from, curr)
+	ret = append(ret, seg)
+	for ts := from; ts < to; {
+		next := ts + pillarScanStep
+		if next > to {
+			next = to
+		}
+
+		g := pillarsAt(next, loc, opts)
+		if !g.samePillars(curr) {
+			// Bisect the change in (ts, next]
+			lo, hi := ts, next
+			for hi-lo > 1 {
+				mid := (lo + hi) / 2
+				if pillarsAt(mid, loc, opts).samePillars(curr) {
+					lo = mid
+				} else {
+					hi = mid
+				}
+			}
+
+			seg.To = hi
+			curr = pillarsAt(hi, loc, opts)
+			seg = _new(hi, curr)
+			ret = append(ret, seg)
+			next = hi
+		}
+
+		ts = next
+	}
+
+	return ret
+}
+
 /*
  * Local variables:
  * tab-width: 4
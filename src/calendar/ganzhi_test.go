@@ -0,0 +1,73 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file ganzhi_test.go
+ * @package calendar
+ */
+
+package calendar
+
+import (
+	"reflect"
+	"testing"
+	"time"
+	"utils"
+)
+
+func TestUncertainPillars(t *testing.T) {
+	var (
+		loc   = utils.Location{Latitude: 39.9, Longitude: 116.4}
+		china = time.FixedZone("CST", 8*3600)
+		cases = []struct {
+			date      time.Time
+			profile   string
+			uncertain []string
+		}{
+			// 立春 2024-02-04 16:27
+			{time.Date(2024, time.February, 4, 12, 0, 0, 0, china), ProfileStandard, []string{"year", "month", "day"}},
+			{time.Date(2024, time.February, 4, 12, 0, 0, 0, china), ProfileZiSplit, []string{"year", "month"}},
+			// 惊蛰 2024-03-05 10:23
+			{time.Date(2024, time.March, 5, 12, 0, 0, 0, china), ProfileZiSplit, []string{"month"}},
+			{time.Date(2024, time.March, 15, 12, 0, 0, 0, china), ProfileStandard, []string{"day"}},
+			{time.Date(2024, time.March, 15, 12, 0, 0, 0, china), ProfileZiSplit, nil},
+		}
+	)
+
+	for _, c := range cases {
+		p, _ := GetProfile(c.profile)
+		g := pillarsAt(c.date.Unix(), loc, Options{HourUnknown: true, Profile: p})
+		if !reflect.DeepEqual(g.Uncertain, c.uncertain) {
+			t.Errorf("%s %s : got %v, want %v", c.date.Format("2006-01-02"), c.profile, g.Uncertain, c.uncertain)
+		}
+	}
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -0,0 +1,285 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file ics.go
+ * @package calendar
+ */
+
+package calendar
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"texts"
+	"time"
+	"unicode/utf8"
+	"utils"
+)
+
+const (
+	// ICSDefaultYears : Default count of years in iCalendar feed
+	ICSDefaultYears = 10
+	// ICSMaxYears : Max count of years in iCalendar feed
+	ICSMaxYears = 100
+
+	// Max octets of content line, longer ones are folded
+	icsLineOctets = 75
+)
+
+// Summaries, index of texts.AliasICSSummary
+const (
+	icsSummaryBirthday = iota
+	icsSummaryBenMing
+	icsSummaryAdjusted
+	icsSummaryLeap
+)
+
+// LunarDate : Date in lunar calendar
+type LunarDate struct {
+	Year  int  `json:"year"`
+	Month int  `json:"month"`
+	Day   int  `json:"day"`
+	Leap  bool `json:"leap"`
+}
+
+// ICSEvent : All-day event of iCalendar feed
+type ICSEvent struct {
+	UID         string
+	Date        time.Time
+	Summary     string
+	Description string
+}
+
+// lunarMonthDays : Days of month in lunar year, leap month if leap
+func lunarMonthDays(y *lunarYear, month int, leap bool) int {
+	idx := month - 1
+	if y.leapMonth > 0 && (month > y.leapMonth || (leap && month == y.leapMonth)) {
+		idx = month
+	}
+
+	return y.days[idx]
+}
+
+// lunarMonthString : Month alias, leap prefixed
+func lunarMonthString(month int, leap bool, language int) string {
+	s := texts.GetAlias(texts.AliasLunarMonth, month-1, language)
+	if leap {
+		s = fmt.Sprintf(texts.GetAlias(texts.AliasICSSummary, icsSummaryLeap, language), s)
+	}
+
+	return s
+}
+
+// LunarBirthday : Lunar date which birthday of year falls on. Birthday of leap month is celebrated in the
+// regular month if year has no such leap month, birthday on 30th in the last day if the month is short
+func LunarBirthday(year int, birth LunarDate) (LunarDate, error) {
+	var (
+		y   = getLunarYear(year)
+		ret = LunarDate{Year: year, Month: birth.Month, Day: birth.Day}
+	)
+
+	if y == nil {
+		return ret, errors.New("Lunar year out of range")
+	}
+
+	if birth.Month < 1 || birth.Month > 12 || birth.Day < 1 || birth.Day > 30 {
+		return ret, errors.New("Invalid lunar date")
+	}
+
+	ret.Leap = birth.Leap && y.leapMonth == birth.Month
+	if days := lunarMonthDays(y, ret.Month, ret.Leap); ret.Day > days {
+		ret.Day = days
+	}
+
+	return ret, nil
+}
+
+// LunarBirthdayEvents : Lunar birthdays of years from <fromYear>
+func LunarBirthdayEvents(birth LunarDate, fromYear, years, language int) ([]*ICSEvent, error) {
+	var (
+		ret        []*ICSEvent
+		birthDay   = texts.GetAlias(texts.AliasLunarDay, birth.Day-1, language)
+		birthMonth = lunarMonthString(birth.Month, birth.Leap, language)
+		summary    = fmt.Sprintf(texts.GetAlias(texts.AliasICSSummary, icsSummaryBirthday, language), birthMonth, birthDay)
+	)
+
+	for year := fromYear; year < fromYear+years; year++ {
+		d, err := LunarBirthday(year, birth)
+		if err != nil {
+			return nil, err
+		}
+
+		t, err := LunarToSolar(d.Year, d.Month, d.Day, d.Leap)
+		if err != nil {
+			return nil, err
+		}
+
+		e := &ICSEvent{
+			UID:     fmt.Sprintf("birthday-%04d%02d%02d-%d", birth.Year, birth.Month, birth.Day, year),
+			Date:    t,
+			Summary: summary,
+		}
+
+		if d.Leap != birth.Leap || d.Day != birth.Day {
+			e.Description = fmt.Sprintf(texts.GetAlias(texts.AliasICSSummary, icsSummaryAdjusted, language),
+				birthMonth,
+				birthDay,
+				lunarMonthString(d.Month, d.Leap, language),
+				texts.GetAlias(texts.AliasLunarDay, d.Day-1, language))
+		}
+
+		ret = append(ret, e)
+	}
+
+	return ret, nil
+}
+
+// BenMingEvents : Lunar new year of years of birth animal sign (本命年) from <fromYear>
+func BenMingEvents(birthYear, fromYear, years, language int) ([]*ICSEvent, error) {
+	var ret []*ICSEvent
+
+	for year := fromYear; year < fromYear+years; year++ {
+		if year <= birthYear || (year-birthYear)%12 != 0 {
+			continue
+		}
+
+		t, err := LunarToSolar(year, 1, 1, false)
+		if err != nil {
+			return nil, err
+		}
+
+		gz := utils.GanzhiPair{TianGan: (year - 4) % 10, DiZhi: (year - 4) % 12}
+		ret = append(ret, &ICSEvent{
+			UID:     fmt.Sprintf("benming-%d-%d", birthYear, year),
+			Date:    t,
+			Summary: fmt.Sprintf(texts.GetAlias(texts.AliasICSSummary, icsSummaryBenMing, language), gz.String(language)),
+		})
+	}
+
+	return ret, nil
+}
+
+// SolartermEvents : Solarterms of years from <fromYear>, at date of China time
+func SolartermEvents(fromYear, years, language int) ([]*ICSEvent, error) {
+	var ret []*ICSEvent
+
+	for year := fromYear; year < fromYear+years; year++ {
+		if err := checkYear(year); err != nil {
+			return nil, err
+		}
+
+		for i, t := range GetSolarterms(year) {
+			ret = append(ret, &ICSEvent{
+				UID:         fmt.Sprintf("solarterm-%d-%02d", year, i),
+				Date:        t,
+				Summary:     texts.GetAlias(texts.AliasSolarterm, i, language),
+				Description: t.Format("2006-01-02 15:04:05 MST"),
+			})
+		}
+	}
+
+	return ret, nil
+}
+
+// icsEscape : Escape TEXT value
+func icsEscape(s string) string {
+	var b bytes.Buffer
+
+	for _, r := range s {
+		switch r {
+		case '\\', ';', ',':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString("\\n")
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// icsLine : Write content line, folded by octets without breaking UTF-8 sequences
+func icsLine(b *bytes.Buffer, line string) {
+	limit := icsLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// Leading space of continuation counts
+		limit = icsLineOctets - 1
+	}
+
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+// RenderICS : iCalendar (RFC 5545) feed of all-day events
+func RenderICS(name string, events []*ICSEvent) []byte {
+	var (
+		b     bytes.Buffer
+		stamp = time.Now().UTC().Format("20060102T150405Z")
+	)
+
+	icsLine(&b, "BEGIN:VCALENDAR")
+	icsLine(&b, "VERSION:2.0")
+	icsLine(&b, "PRODID:-//HereweTech//Naming//ZH")
+	icsLine(&b, "CALSCALE:GREGORIAN")
+	icsLine(&b, "METHOD:PUBLISH")
+	icsLine(&b, "X-WR-CALNAME:"+icsEscape(name))
+	for _, e := range events {
+		icsLine(&b, "BEGIN:VEVENT")
+		icsLine(&b, "UID:"+e.UID+"@naming")
+		icsLine(&b, "DTSTAMP:"+stamp)
+		icsLine(&b, "DTSTART;VALUE=DATE:"+e.Date.Format("20060102"))
+		icsLine(&b, "DTEND;VALUE=DATE:"+e.Date.AddDate(0, 0, 1).Format("20060102"))
+		icsLine(&b, "SUMMARY:"+icsEscape(e.Summary))
+		if e.Description != "" {
+			icsLine(&b, "DESCRIPTION:"+icsEscape(e.Description))
+		}
+
+		icsLine(&b, "TRANSP:TRANSPARENT")
+		icsLine(&b, "END:VEVENT")
+	}
+
+	icsLine(&b, "END:VCALENDAR")
+
+	return b.Bytes()
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -31,17 +31,35 @@
 package calendar
 
 import (
+	"errors"
+	"math"
+	"sync"
 	"time"
+	"utils"
 )
 
 type lunarYear struct {
 	year      int
+	start     time.Time
 	days      []int
 	totalDays int
 	leapMonth int
 	leapType  int
 }
 
+type lunarMonth struct {
+	start  int
+	number int
+	leap   bool
+}
+
+const (
+	// LunarMinYear : Lunar years computed from this year
+	LunarMinYear = 1000
+	// LunarMaxYear : Lunar years computed until this year
+	LunarMaxYear = 3000
+)
+
 var (
 	lunarVars = []int64{
 		0x04bd8, 0x04ae0, 0x0a570, 0x054d5, 0x0d260, 0x0d950, 0x16554, 0x056a0, 0x09ad0, 0x055d2, // 1900-1909
@@ -70,6 +88,13 @@ var (
 	lunarStart = time.Date(1900, 1, 30, 16, 0, 0, 0, time.UTC)
 	lunarYears []*lunarYear
 
+	// Computed years out of lunarVars
+	lunarYearsComputed     = make(map[int]*lunarYear)
+	lunarYearsComputedLock sync.RWMutex
+
+	// China standard time (UTC+8) since 1929, Beijing mean time before
+	jd1929 = julianDay(time.Date(1929, 1, 1, 0, 0, 0, 0, time.UTC))
+
 	animalSignAliases = []string{"鼠", "牛", "虎", "兔", "龙", "蛇", "马", "羊", "猴", "鸡", "狗", "猪", ""}
 	lunarMonthAliases = []string{"正", "二", "三", "四", "五", "六", "七", "八", "九", "十", "冬", "腊", ""}
 	lunarDayAliases   = []string{
@@ -138,31 +163,25 @@ type lunar struct {
 // complete : Calculate the full lunar
 func (l *lunar) complete() {
 	var (
-		d          = l.t.Sub(lunarStart)
 		subDays    int
 		remainDays int
 		m          int
-		y          = lunarYears[0]
+		y          = getLunarYear(l.t.Year())
 	)
 
-	subDays = int(d.Hours()) / 24
-	remainDays = subDays
-	for m = 0; m <= 200; m++ {
-		y = lunarYears[m]
-		if y != nil {
-			remainDays = subDays
-			subDays -= y.totalDays
-		}
+	if y != nil && l.t.Before(y.start) {
+		y = getLunarYear(l.t.Year() - 1)
+	}
 
-		if subDays < 0 {
-			break
-		}
+	if y == nil {
+		// Out of range
+		return
 	}
 
+	subDays = int(l.t.Sub(y.start).Hours()) / 24
 	l.Year = y.year
 	l.YearMonths = len(y.days)
 	l.YearDays = y.totalDays
-	subDays = remainDays
 	for m = 0; m < len(y.days); m++ {
 		remainDays = subDays
 		subDays -= y.days[m]
@@ -177,7 +196,7 @@ func (l *lunar) complete() {
 			l.LeapMonth = true
 		}
 
-		if m > y.leapMonth {
+		if m >= y.leapMonth {
 			l.Month = m
 		}
 	}
@@ -200,11 +219,258 @@ func (l *lunar) complete() {
 	return
 }
 
+// LunarToSolar : Solar date (midnight of China time) of given lunar date
+func LunarToSolar(year, month, day int, leap bool) (time.Time, error) {
+	var (
+		y    *lunarYear
+		idx  int
+		days int
+	)
+
+	y = getLunarYear(year)
+	if y == nil {
+		return time.Time{}, errors.New("Lunar year out of range")
+	}
+
+	if month < 1 || month > 12 {
+		return time.Time{}, errors.New("Invalid lunar month")
+	}
+
+	if leap && month != y.leapMonth {
+		return time.Time{}, errors.New("Not a leap month")
+	}
+
+	// Leap month inserted after the month of the same number
+	idx = month - 1
+	if y.leapMonth > 0 && (month > y.leapMonth || (leap && month == y.leapMonth)) {
+		idx = month
+	}
+
+	if day < 1 || day > y.days[idx] {
+		return time.Time{}, errors.New("Invalid lunar day")
+	}
+
+	for m := 0; m < idx; m++ {
+		days += y.days[m]
+	}
+
+	days += day - 1
+	loc, _ := time.LoadLocation("Asia/Shanghai")
+
+	return y.start.Add(time.Duration(days) * 24 * time.Hour).In(loc), nil
+}
+
+// InLunarRange : If year (China time) of timestamp is in range lunar calendar supports
+func InLunarRange(timestamp int64) bool {
+	loc, _ := time.LoadLocation("Asia/Shanghai")
+	year := time.Unix(timestamp, 0).In(loc).Year()
+
+	return year >= LunarMinYear && year <= LunarMaxYear
+}
+
+// LunarTimestamp : Timestamp of lunar date at the middle of given shichen (in solar time of profile at location), noon if shichen < 0
+func LunarTimestamp(year, month, day int, leap bool, shichen int, loc utils.Location, p Profile) (int64, error) {
+	var hour = 12
+
+	t, err := LunarToSolar(year, month, day, leap)
+	if err != nil {
+		return 0, err
+	}
+
+	if shichen >= 0 {
+		if shichen > 11 {
+			return 0, errors.New("Invalid shichen")
+		}
+
+		// ZiShi : 23:00 - 01:00, middle at 00:00 of the day
+		hour = shichen * 2
+	}
+
+	return SolarTimeTimestamp(t.Year(), t.Month(), t.Day(), hour, 0, 0, loc, p.SolarTime), nil
+}
+
+// chinaDayNumber : Julian day number of the civil date in China of Julian day (UT)
+func chinaDayNumber(jd float64) int {
+	offset := 8.0 / 24
+	if jd < jd1929 {
+		// Beijing mean time, 116°25'E
+		offset = (7*3600 + 45*60 + 40) / 86400.0
+	}
+
+	return int(math.Floor(jd + 0.5 + offset))
+}
+
+// dayNumberTime : Midnight (China time) of Julian day number
+func dayNumberTime(dn int) time.Time {
+	loc, _ := time.LoadLocation("Asia/Shanghai")
+
+	return time.Date(2000, 1, 1, 0, 0, 0, 0, loc).AddDate(0, 0, dn-2451545)
+}
+
+// newMoonOnOrBefore : Index of the last new moon on or before day number
+func newMoonOnOrBefore(dn int) int {
+	k := newMoonIndex(float64(dn))
+	for chinaDayNumber(newMoon(k)) > dn {
+		k--
+	}
+
+	for chinaDayNumber(newMoon(k+1)) <= dn {
+		k++
+	}
+
+	return k
+}
+
+// suiMonths : Lunar months from the month of DongZhi of previous year to the one before DongZhi of given year
+func suiMonths(year int) []lunarMonth {
+	var (
+		ws1     = chinaDayNumber(julianDay(GetSolarterms(year - 1)[23]))
+		ws2     = chinaDayNumber(julianDay(GetSolarterms(year)[23]))
+		k1      = newMoonOnOrBefore(ws1)
+		k2      = newMoonOnOrBefore(ws2)
+		zhongqi = []int{ws1}
+		leap    = k2-k1 == 13
+		number  = 10
+		ret     []lunarMonth
+	)
+
+	// ZhongQi : DaHan, YuShui, ChunFen ... DongZhi
+	for s := 1; s < 24; s += 2 {
+		zhongqi = append(zhongqi, chinaDayNumber(julianDay(GetSolarterms(year)[s])))
+	}
+
+	for k := k1; k < k2; k++ {
+		m := lunarMonth{
+			start: chinaDayNumber(newMoon(k)),
+		}
+
+		if leap && k > k1 {
+			// The first month without ZhongQi is the leap month
+			end := chinaDayNumber(newMoon(k + 1))
+			m.leap = true
+			for _, z := range zhongqi {
+				if z >= m.start && z < end {
+					m.leap = false
+					break
+				}
+			}
+
+			if m.leap {
+				leap = false
+			}
+		}
+
+		if !m.leap {
+			number = number%12 + 1
+		}
+
+		m.number = number
+		ret = append(ret, m)
+	}
+
+	return ret
+}
+
+// computeLunarYear : Compute lunar year by new moons and ZhongQi
+func computeLunarYear(year int) *lunarYear {
+	var (
+		months     = append(suiMonths(year), suiMonths(year+1)...)
+		begin, end = -1, -1
+		ret        = &lunarYear{year: year}
+	)
+
+	for i, m := range months {
+		if m.number == 1 && !m.leap {
+			if begin < 0 {
+				begin = i
+			} else {
+				end = i
+				break
+			}
+		}
+	}
+
+	if begin < 0 || end < 0 {
+		return nil
+	}
+
+	ret.start = dayNumberTime(months[begin].start)
+	for i := begin; i < end; i++ {
+		d := months[i+1].start - months[i].start
+		ret.days = append(ret.days, d)
+		ret.totalDays += d
+		if months[i].leap {
+			ret.leapMonth = months[i].number
+			if d == 30 {
+				ret.leapType = 1
+			}
+		}
+	}
+
+	return ret
+}
+
+// getLunarYear : Lunar year from lunarVars, or computed out of it
+func getLunarYear(year int) *lunarYear {
+	if year >= lunarYears[0].year && year <= lunarYears[len(lunarYears)-1].year {
+		return lunarYears[year-lunarYears[0].year]
+	}
+
+	if year < LunarMinYear || year > LunarMaxYear {
+		return nil
+	}
+
+	lunarYearsComputedLock.RLock()
+	y := lunarYearsComputed[year]
+	lunarYearsComputedLock.RUnlock()
+	if y != nil {
+		return y
+	}
+
+	y = computeLunarYear(year)
+	if y != nil {
+		lunarYearsComputedLock.Lock()
+		lunarYearsComputed[year] = y
+		lunarYearsComputedLock.Unlock()
+	}
+
+	return y
+}
+
+// CheckLunarTable : Cross-check lunarVars with computed lunar years, return years differ
+func CheckLunarTable() []int {
+	var ret []int
+
+	loc, _ := time.LoadLocation("Asia/Shanghai")
+	for _, y := range lunarYears {
+		c := computeLunarYear(y.year)
+		if c == nil ||
+			c.start.In(loc).Format("2006-01-02") != y.start.In(loc).Format("2006-01-02") ||
+			c.leapMonth != y.leapMonth ||
+			len(c.days) != len(y.days) {
+			ret = append(ret, y.year)
+			continue
+		}
+
+		for i := range c.days {
+			if c.days[i] != y.days[i] {
+				ret = append(ret, y.year)
+				break
+			}
+		}
+	}
+
+	return ret
+}
+
 // Parse all vars
 func init() {
+	start := lunarStart
 	for m := 0; m <= 200; m++ {
 		y := parseVar(lunarVars[m])
 		y.year = 1900 + m
+		y.start = start
+		start = start.Add(time.Duration(y.totalDays) * 24 * time.Hour)
 		lunarYears = append(lunarYears, y)
 	}
 
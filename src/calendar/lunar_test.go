@@ -0,0 +1,124 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file lunar_test.go
+ * @package calendar
+ */
+
+package calendar
+
+import (
+	"testing"
+)
+
+func TestLunarToSolar(t *testing.T) {
+	var cases = []struct {
+		year  int
+		month int
+		day   int
+		leap  bool
+		date  string
+	}{
+		// Spring festivals
+		{1984, 1, 1, false, "1984-02-02"},
+		{2000, 1, 1, false, "2000-02-05"},
+		{2020, 1, 1, false, "2020-01-25"},
+		{2023, 1, 1, false, "2023-01-22"},
+		{2024, 1, 1, false, "2024-02-10"},
+		{2025, 1, 1, false, "2025-01-29"},
+		// Mid-autumn festival
+		{2024, 8, 15, false, "2024-09-17"},
+		// Leap months
+		{2020, 4, 1, true, "2020-05-23"},
+		{2023, 2, 1, true, "2023-03-22"},
+		{2033, 11, 1, true, "2033-12-22"},
+	}
+
+	for _, c := range cases {
+		got, err := LunarToSolar(c.year, c.month, c.day, c.leap)
+		if err != nil {
+			t.Errorf("%d-%d-%d : %s", c.year, c.month, c.day, err)
+			continue
+		}
+
+		if got.Format("2006-01-02") != c.date {
+			t.Errorf("%d-%d-%d : got %s, want %s", c.year, c.month, c.day, got.Format("2006-01-02"), c.date)
+		}
+	}
+}
+
+func TestLunarLeapMonth(t *testing.T) {
+	var cases = []struct {
+		year      int
+		leapMonth int
+	}{
+		{2017, 6},
+		{2019, 0},
+		{2020, 4},
+		{2023, 2},
+		{2025, 6},
+		{2033, 11},
+	}
+
+	for _, c := range cases {
+		y := getLunarYear(c.year)
+		if y == nil || y.leapMonth != c.leapMonth {
+			t.Errorf("%d : leap month differs, want %d", c.year, c.leapMonth)
+		}
+	}
+}
+
+func TestCheckLunarTable(t *testing.T) {
+	// New moons within minutes of China midnight (and Beijing local time before 1929),
+	// where published tables and computation move one month boundary by a day
+	var known = map[int]bool{
+		1906: true,
+		1933: true,
+		1996: true,
+		2057: true,
+		2060: true,
+	}
+
+	for _, year := range CheckLunarTable() {
+		if !known[year] {
+			t.Errorf("Computed lunar year %d differs from table", year)
+
+			continue
+		}
+
+		c, y := computeLunarYear(year), getLunarYear(year)
+		if !c.start.Equal(y.start) || c.leapMonth != y.leapMonth || c.totalDays != y.totalDays {
+			t.Errorf("Computed lunar year %d differs from table more than a month boundary", year)
+		}
+	}
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
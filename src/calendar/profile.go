@@ -0,0 +1,140 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file profile.go
+ * @package calendar
+ */
+
+package calendar
+
+import (
+	"sort"
+)
+
+const (
+	// SolarTimeReal : Ganzhi by real-sun (apparent solar) time of location
+	SolarTimeReal = 0
+	// SolarTimeMean : Ganzhi by mean solar time of location (longitude only)
+	SolarTimeMean = 1
+	// SolarTimeClock : Ganzhi by China clock time, as printed in most almanacs
+	SolarTimeClock = 2
+
+	// ProfileStandard : Real-sun time, day changes at 23:00, animal sign by lunar new year
+	ProfileStandard = "standard"
+	// ProfileZiSplit : ZaoZiShi / WanZiShi, day changes at midnight
+	ProfileZiSplit = "zi_split"
+	// ProfileLiChun : Animal sign changes at LiChun with the year pillar
+	ProfileLiChun = "lichun"
+	// ProfileMeanSolar : Mean solar time of location
+	ProfileMeanSolar = "mean_solar"
+	// ProfileClock : China clock time without solar correction
+	ProfileClock = "clock"
+)
+
+// Profile : Convention profile of calendar calculation
+type Profile struct {
+	Name string `json:"name"`
+	// 23:00 - 24:00 is WanZiShi of the day, day pillar changes at midnight
+	ZiSplit bool `json:"zi_split"`
+	// Animal sign by LiChun instead of lunar new year
+	AnimalByLiChun bool `json:"animal_by_lichun"`
+	// Time of ganzhi, SolarTimeReal / SolarTimeMean / SolarTimeClock
+	SolarTime int `json:"solar_time"`
+}
+
+var (
+	profiles = map[string]Profile{
+		ProfileStandard: {
+			Name: ProfileStandard,
+		},
+		ProfileZiSplit: {
+			Name:    ProfileZiSplit,
+			ZiSplit: true,
+		},
+		ProfileLiChun: {
+			Name:           ProfileLiChun,
+			AnimalByLiChun: true,
+		},
+		ProfileMeanSolar: {
+			Name:      ProfileMeanSolar,
+			SolarTime: SolarTimeMean,
+		},
+		ProfileClock: {
+			Name:      ProfileClock,
+			SolarTime: SolarTimeClock,
+		},
+	}
+
+	// ProfileDefault : Name of profile used if not given
+	ProfileDefault = ProfileStandard
+)
+
+// Solar time modes by name
+var solarTimes = map[string]int{
+	"real":  SolarTimeReal,
+	"mean":  SolarTimeMean,
+	"clock": SolarTimeClock,
+}
+
+// ParseSolarTime : Solar time mode by name, real / mean / clock
+func ParseSolarTime(name string) (int, bool) {
+	st, ok := solarTimes[name]
+
+	return st, ok
+}
+
+// GetProfile : Profile by name, default profile if name is empty
+func GetProfile(name string) (Profile, bool) {
+	if name == "" {
+		name = ProfileDefault
+	}
+
+	p, ok := profiles[name]
+
+	return p, ok
+}
+
+// ListProfiles : All profiles sorted by name
+func ListProfiles() []Profile {
+	var ret []Profile
+
+	for _, p := range profiles {
+		ret = append(ret, p)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Name < ret[j].Name
+	})
+
+	return ret
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -0,0 +1,88 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file solar_test.go
+ * @package calendar
+ */
+
+package calendar
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestGetSolarterms(t *testing.T) {
+	var cases = []struct {
+		year  int
+		index int
+		time  string
+	}{
+		{2000, 5, "2000-03-20 15:35"},
+		{2000, 23, "2000-12-21 21:37"},
+		{2023, 2, "2023-02-04 10:42"},
+		{2024, 2, "2024-02-04 16:27"},
+		{2024, 5, "2024-03-20 11:06"},
+		{2024, 11, "2024-06-21 04:51"},
+		{2024, 23, "2024-12-21 17:20"},
+	}
+
+	loc, _ := time.LoadLocation("Asia/Shanghai")
+	for _, c := range cases {
+		want, _ := time.ParseInLocation("2006-01-02 15:04", c.time, loc)
+		got := GetSolarterms(c.year)[c.index]
+		if d := got.Sub(want); d < -2*time.Minute || d > 2*time.Minute {
+			t.Errorf("%d %s : got %s, want %s", c.year, solartermAliases[c.index], got.Format("2006-01-02 15:04:05"), c.time)
+		}
+	}
+}
+
+func TestEquationOfTime(t *testing.T) {
+	var cases = []struct {
+		date    time.Time
+		seconds float64
+	}{
+		// Meeus, example 28.b
+		{time.Date(1992, time.October, 13, 0, 0, 0, 0, time.UTC), 822.6},
+		{time.Date(2024, time.February, 11, 12, 0, 0, 0, time.UTC), -853},
+		{time.Date(2024, time.November, 3, 12, 0, 0, 0, time.UTC), 984},
+	}
+
+	for _, c := range cases {
+		got := equationOfTime(julianDay(c.date))
+		if math.Abs(got-c.seconds) > 10 {
+			t.Errorf("%s : got %.1fs, want %.1fs", c.date.Format("2006-01-02"), got, c.seconds)
+		}
+	}
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
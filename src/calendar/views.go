@@ -0,0 +1,181 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file views.go
+ * @package calendar
+ */
+
+package calendar
+
+import (
+	"errors"
+	"texts"
+	"time"
+	"utils"
+)
+
+// Solarterm : Solarterm of year
+type Solarterm struct {
+	Index     int      `json:"index"`
+	Name      string   `json:"name"`
+	Timestamp int64    `json:"timestamp"`
+	Time      timeSpec `json:"time"`
+}
+
+// MonthDay : Day of month view
+type MonthDay struct {
+	Day        int      `json:"day"`
+	Week       int      `json:"week"`
+	Lunar      lunar    `json:"lunar"`
+	Ganzhi     ganzhi   `json:"ganzhi"`
+	Solarterms []string `json:"solarterms,omitempty"`
+	Almanac    *Almanac `json:"almanac,omitempty"`
+}
+
+// Localize : Fill aliases of lunar and ganzhi in language
+func (c *Calendar) Localize(language int) {
+	c.Ganzhi.YearString = c.Ganzhi.Year.String(language)
+	c.Ganzhi.MonthString = c.Ganzhi.Month.String(language)
+	c.Ganzhi.DayString = c.Ganzhi.Day.String(language)
+	c.Ganzhi.HourString = c.Ganzhi.Hour.String(language)
+	if c.Lunar.Month > 0 && c.Lunar.Day > 0 {
+		y := utils.GanzhiPair{TianGan: (c.Lunar.Year - 4) % 10, DiZhi: (c.Lunar.Year - 4) % 12}
+		c.Lunar.YearString = y.String(language)
+		c.Lunar.MonthString = texts.GetAlias(texts.AliasLunarMonth, c.Lunar.Month-1, language)
+		c.Lunar.DayString = texts.GetAlias(texts.AliasLunarDay, c.Lunar.Day-1, language)
+		c.Lunar.AnimalSignString = texts.GetAlias(texts.AliasAnimal, c.Lunar.AnimalSign, language)
+	}
+
+	return
+}
+
+// checkYear : If year in range of calendar
+func checkYear(year int) error {
+	if year < LunarMinYear || year > LunarMaxYear {
+		return errors.New("Year out of range")
+	}
+
+	return nil
+}
+
+// ListSolarterms : Solarterms (XiaoHan to DongZhi) of year in language
+func ListSolarterms(year int, language int) ([]*Solarterm, error) {
+	var ret []*Solarterm
+
+	if err := checkYear(year); err != nil {
+		return nil, err
+	}
+
+	for idx, t := range GetSolarterms(year) {
+		st := &Solarterm{
+			Index:     idx,
+			Name:      texts.GetAlias(texts.AliasSolarterm, idx, language),
+			Timestamp: t.Unix(),
+		}
+
+		st.Time.parse(t)
+		ret = append(ret, st)
+	}
+
+	return ret, nil
+}
+
+// monthDays : Days of month, from day (1 based) and count of days, with almanac or not
+func monthDays(year, month, day, count int, language int, almanac bool) ([]*MonthDay, error) {
+	var (
+		loc, _ = time.LoadLocation("Asia/Shanghai")
+		opts   Options
+		ret    []*MonthDay
+		terms  []time.Time
+	)
+
+	if err := checkYear(year); err != nil {
+		return nil, err
+	}
+
+	if month < 1 || month > 12 {
+		return nil, errors.New("Invalid month")
+	}
+
+	if day < 1 || day > time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, loc).Day() {
+		return nil, errors.New("Invalid day")
+	}
+
+	terms = GetSolarterms(year)
+	opts.Profile, _ = GetProfile(ProfileClock)
+	opts.HourUnknown = true
+	for t := time.Date(year, time.Month(month), day, 12, 0, 0, 0, loc); int(t.Month()) == month && len(ret) < count; t = t.AddDate(0, 0, 1) {
+		c := NewWithOptions(t.Unix(), utils.Location{}, opts)
+		c.Localize(language)
+		d := &MonthDay{
+			Day:    t.Day(),
+			Week:   int(t.Weekday()),
+			Lunar:  c.Lunar,
+			Ganzhi: c.Ganzhi,
+		}
+
+		for idx, st := range terms {
+			if st.Year() == t.Year() && st.YearDay() == t.YearDay() {
+				d.Solarterms = append(d.Solarterms, texts.GetAlias(texts.AliasSolarterm, idx, language))
+			}
+		}
+
+		if almanac {
+			d.Almanac = newAlmanac(&c.Ganzhi, language)
+		}
+
+		ret = append(ret, d)
+	}
+
+	return ret, nil
+}
+
+// MonthView : Lunar date, ganzhi (three pillars by China clock time) and solarterms of each day of month
+func MonthView(year int, month int, language int) ([]*MonthDay, error) {
+	return monthDays(year, month, 1, 31, language, false)
+}
+
+// AlmanacMonth : Month view with almanac of each day
+func AlmanacMonth(year int, month int, language int) ([]*MonthDay, error) {
+	return monthDays(year, month, 1, 31, language, true)
+}
+
+// AlmanacDay : Day with almanac
+func AlmanacDay(year, month, day int, language int) (*MonthDay, error) {
+	ret, err := monthDays(year, month, day, 1, language, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret[0], nil
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -48,6 +48,35 @@ type BaiJiaXing struct {
 var baiJiaXingMSimplified map[string]*BaiJiaXing
 var baiJiaXingMTraditional map[string]*BaiJiaXing
 
+// Compound surnames (复姓) of BaiJiaXing and later ones, true for common
+var compoundSurnames = map[string]bool{
+	"欧阳": true, "司马": true, "上官": true, "诸葛": true, "东方": true, "皇甫": true,
+	"尉迟": true, "公孙": true, "慕容": true, "令狐": true, "长孙": true, "宇文": true,
+	"司徒": true, "夏侯": true, "轩辕": true, "端木": true, "独孤": true, "南宫": true,
+	"西门": true, "司空": true, "呼延": true, "澹台": true, "公冶": true, "赫连": true,
+	"万俟": false, "闻人": false, "公羊": false, "宗政": false, "濮阳": false, "淳于": false,
+	"单于": false, "太叔": false, "申屠": false, "仲孙": false, "钟离": false, "鲜于": false,
+	"闾丘": false, "亓官": false, "司寇": false, "子车": false, "颛孙": false, "巫马": false,
+	"公西": false, "漆雕": false, "乐正": false, "壤驷": false, "公良": false, "拓跋": false,
+	"夹谷": false, "宰父": false, "谷梁": false, "段干": false, "百里": false, "东郭": false,
+	"南门": false, "羊舌": false, "微生": false, "梁丘": false, "左丘": false, "东门": false,
+	"第五": false, "完颜": false, "叱干": false, "贺兰": false, "耶律": false, "爱新觉罗": false,
+}
+
+// QueryCompoundSurname : Check compound surname (simplified) in list or BaiJiaXing, and if it is a common one
+func QueryCompoundSurname(familyName string) (bool, bool) {
+	common, ok := compoundSurnames[familyName]
+	if ok {
+		return true, common
+	}
+
+	if len([]rune(familyName)) > 1 && QueryBaiJiaXing(familyName) != nil {
+		return true, false
+	}
+
+	return false, false
+}
+
 // QueryBaiJiaXing : Check and query baijiaxing
 func QueryBaiJiaXing(familyName string) *BaiJiaXing {
 	if baiJiaXingMSimplified != nil {
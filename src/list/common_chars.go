@@ -34,6 +34,7 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 )
 
@@ -96,6 +97,34 @@ func CountCommonL2() int {
 	return len(commonCharactersL2)
 }
 
+// ListCommonL1 : Characters of common L1, most frequent first
+func ListCommonL1() []rune {
+	return listCommon(commonCharactersL1)
+}
+
+// ListCommonL2 : Characters of common L2, most frequent first
+func ListCommonL2() []rune {
+	return listCommon(commonCharactersL2)
+}
+
+func listCommon(m map[rune]int32) []rune {
+	var ret = make([]rune, 0, len(m))
+
+	for r := range m {
+		ret = append(ret, r)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		if m[ret[i]] != m[ret[j]] {
+			return m[ret[i]] > m[ret[j]]
+		}
+
+		return ret[i] < ret[j]
+	})
+
+	return ret
+}
+
 // TraditionalizeCommonCharacters : Traditionalize common list
 func TraditionalizeCommonCharacters() int {
 	return 0
@@ -0,0 +1,276 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file gazetteer.go
+ * @package list
+ */
+
+package list
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"utils"
+)
+
+const (
+	// PlaceLevelProvince : Province, municipality or autonomous region
+	PlaceLevelProvince = 1
+	// PlaceLevelPrefecture : Prefecture-level city, league or autonomous prefecture
+	PlaceLevelPrefecture = 2
+	// PlaceLevelCounty : County, county-level city or district
+	PlaceLevelCounty = 3
+)
+
+// Place : Administrative division of gazetteer
+type Place struct {
+	Code            string   `json:"code"`
+	Name            string   `json:"name"`
+	FullName        string   `json:"full_name"`
+	Level           int      `json:"level"`
+	Parent          string   `json:"parent,omitempty"`
+	Longitude       float64  `json:"longitude"`
+	Latitude        float64  `json:"latitude"`
+	HistoricalNames []string `json:"historical_names,omitempty"`
+}
+
+var (
+	placesM      map[string]*Place
+	placesNameM  map[string][]*Place
+	placesSorted []*Place
+
+	// Suffixes can be omitted in place names, longer first
+	placeSuffixes = []string{
+		"特别行政区", "维吾尔自治区", "壮族自治区", "回族自治区", "自治区", "自治州", "自治县", "自治旗",
+		"地区", "新区", "林区", "省", "市", "县", "区", "盟", "旗",
+	}
+)
+
+// Location : Location of place used by calendar
+func (p *Place) Location() utils.Location {
+	return utils.Location{
+		Latitude:  p.Latitude,
+		Longitude: p.Longitude,
+	}
+}
+
+// placeShortName : Name without administrative suffix, as "南京" of "南京市"
+func placeShortName(name string) string {
+	for _, suffix := range placeSuffixes {
+		short := strings.TrimSuffix(name, suffix)
+		if short != name && len([]rune(short)) >= 2 {
+			return short
+		}
+	}
+
+	return name
+}
+
+// placeNames : All names the place can be queried by
+func placeNames(p *Place) []string {
+	var ret []string
+
+	for _, name := range append([]string{p.Name, p.FullName}, p.HistoricalNames...) {
+		ret = append(ret, name)
+		if short := placeShortName(name); short != name {
+			ret = append(ret, short)
+		}
+	}
+
+	return ret
+}
+
+// QueryPlaceByCode : Query place by administrative division code
+func QueryPlaceByCode(code string) *Place {
+	if placesM != nil {
+		return placesM[code]
+	}
+
+	return nil
+}
+
+// QueryPlaces : Query places by name, short name, full name or historical name, upper levels first
+func QueryPlaces(name string) []*Place {
+	if placesNameM != nil {
+		return placesNameM[strings.TrimSpace(name)]
+	}
+
+	return nil
+}
+
+// GazetteerLoaded : If gazetteer list loaded, places can not be resolved otherwise
+func GazetteerLoaded() bool {
+	return placesM != nil
+}
+
+// QueryPlace : Resolve administrative division code or place name to places, more than one if name is ambiguous
+func QueryPlace(input string) []*Place {
+	if p := QueryPlaceByCode(input); p != nil {
+		return []*Place{p}
+	}
+
+	return QueryPlaces(input)
+}
+
+// SuggestPlaces : Places with any name starts with prefix, for autocomplete
+func SuggestPlaces(prefix string, limit int) []*Place {
+	var ret []*Place
+
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" || limit <= 0 {
+		return nil
+	}
+
+	for _, p := range placesSorted {
+		for _, name := range placeNames(p) {
+			if strings.HasPrefix(name, prefix) || strings.HasPrefix(p.Code, prefix) {
+				ret = append(ret, p)
+				break
+			}
+		}
+
+		if len(ret) >= limit {
+			break
+		}
+	}
+
+	return ret
+}
+
+// LoadGazetteer : Load place names from gazetteer list <dir>/list/Gazetteer.txt, optional.
+// One administrative division each line, "#" for comments, fields separated by comma:
+// Code,Name,Level,ParentCode,Longitude,Latitude[,HistoricalName|HistoricalName...]
+// such as "110000,北京市,1,,116.4074,39.9042,北平|燕京". Code is the GB/T 2260 division code,
+// level 1 for province, 2 for prefecture and 3 for county, longitude and latitude in signed degrees
+func LoadGazetteer(dir string) (int, error) {
+	var (
+		fullPath string
+		f        *os.File
+		err      error
+		scanner  *bufio.Scanner
+		line     string
+		parts    []string
+		level    int
+		lng, lat float64
+		total    int
+	)
+
+	placesM = make(map[string]*Place)
+	placesNameM = make(map[string][]*Place)
+	placesSorted = nil
+	fullPath = fmt.Sprintf("%s/list/Gazetteer.txt", dir)
+	f, err = os.Open(fullPath)
+	if err != nil {
+		placesM = nil
+		placesNameM = nil
+		return 0, fmt.Errorf("Load list file <%s> failed", fullPath)
+	}
+
+	// Code,Name,Level,ParentCode,Longitude,Latitude,HistoricalName|HistoricalName...
+	scanner = bufio.NewScanner(f)
+	for scanner.Scan() == true {
+		line = strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts = strings.Split(line, ",")
+		if len(parts) < 6 {
+			continue
+		}
+
+		level, err = strconv.Atoi(parts[2])
+		if err != nil {
+			continue
+		}
+
+		lng, err = strconv.ParseFloat(parts[4], 64)
+		if err != nil {
+			continue
+		}
+
+		lat, err = strconv.ParseFloat(parts[5], 64)
+		if err != nil {
+			continue
+		}
+
+		p := &Place{
+			Code:      parts[0],
+			Name:      parts[1],
+			Level:     level,
+			Parent:    parts[3],
+			Longitude: lng,
+			Latitude:  lat,
+		}
+
+		if len(parts) > 6 && parts[6] != "" {
+			p.HistoricalNames = strings.Split(parts[6], "|")
+		}
+
+		placesM[p.Code] = p
+		placesSorted = append(placesSorted, p)
+		total++
+	}
+
+	f.Close()
+
+	sort.SliceStable(placesSorted, func(i, j int) bool {
+		if placesSorted[i].Level != placesSorted[j].Level {
+			return placesSorted[i].Level < placesSorted[j].Level
+		}
+
+		return placesSorted[i].Code < placesSorted[j].Code
+	})
+
+	for _, p := range placesSorted {
+		// Full name from province
+		p.FullName = p.Name
+		for child, parent := p, placesM[p.Parent]; parent != nil && parent.Level < child.Level; child, parent = parent, placesM[parent.Parent] {
+			p.FullName = parent.Name + p.FullName
+		}
+
+		names := make(map[string]bool)
+		for _, name := range placeNames(p) {
+			if !names[name] {
+				names[name] = true
+				placesNameM[name] = append(placesNameM[name], p)
+			}
+		}
+	}
+
+	return total, nil
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -0,0 +1,279 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file auspicious.go
+ * @package name
+ */
+
+package name
+
+import (
+	"calendar"
+	"fmt"
+	"sort"
+	"texts"
+	"utils"
+)
+
+const (
+	// AuspiciousMaxRange : Max range of auspicious time search in seconds
+	AuspiciousMaxRange = 86400 * 15
+	// AuspiciousDefaultLimit : Default number of slots returned
+	AuspiciousDefaultLimit = 12
+)
+
+// Reasons, index of texts.AliasAuspiciousReason
+const (
+	reasonLing = iota
+	reasonNoLing
+	reasonShi
+	reasonNoShi
+	reasonDi
+	reasonNoDi
+	reasonStrong
+	reasonWeak
+	reasonMissing
+	reasonComplete
+	reasonLike
+	reasonName
+)
+
+// AuspiciousSlot : Shichen slot of auspicious time search
+type AuspiciousSlot struct {
+	From            int64                   `json:"from"`
+	To              int64                   `json:"to"`
+	YearString      string                  `json:"year_alias"`
+	MonthString     string                  `json:"month_alias"`
+	DayString       string                  `json:"day_alias"`
+	HourString      string                  `json:"hour_alias"`
+	EightCharacters eightCharacters         `json:"eight_characters"`
+	FiveElements    utils.FiveElementsCount `json:"five_elements"`
+	Favourable      *Favourable             `json:"favourable"`
+	ScoreBalance    int                     `json:"score_balance"`
+	ScoreStrength   int                     `json:"score_strength"`
+	ScoreName       int                     `json:"score_name"`
+	Score           int                     `json:"score"`
+	Reasons         []string                `json:"reasons"`
+}
+
+// AuspiciousResult : Result of auspicious time search
+type AuspiciousResult struct {
+	From  int64             `json:"from"`
+	To    int64             `json:"to"`
+	Total int               `json:"total"`
+	Slots []*AuspiciousSlot `json:"slots"`
+}
+
+// clampScore : Limit score in 0 - 100
+func clampScore(score int) int {
+	if score < 0 {
+		return 0
+	}
+
+	if score > 100 {
+		return 100
+	}
+
+	return score
+}
+
+// scoreSlot : Score chart balance and strength of the day master, with reasons
+func (slot *AuspiciousSlot) scoreSlot(language int) {
+	var (
+		ec      = &slot.EightCharacters
+		fe      = slot.FiveElements
+		counts  = []int{fe.Wood, fe.Fire, fe.Earth, fe.Metal, fe.Water}
+		min     = counts[0]
+		max     = counts[0]
+		missing []string
+		_reason = func(idx int, args ...interface{}) {
+			r := texts.GetAlias(texts.AliasAuspiciousReason, idx, language)
+			if len(args) > 0 {
+				r = fmt.Sprintf(r, args...)
+			}
+
+			slot.Reasons = append(slot.Reasons, r)
+		}
+	)
+
+	for i, c := range counts {
+		if c < min {
+			min = c
+		}
+
+		if c > max {
+			max = c
+		}
+
+		if c == 0 {
+			missing = append(missing, texts.GetAlias(texts.AliasFiveElement, i, language))
+		}
+	}
+
+	// Balance : spread of elements and missing ones
+	slot.ScoreBalance = clampScore(100 - (max-min)*8 - len(missing)*15)
+
+	// Strength : Ling + ShiYi near the threshold (50) of stretch is neutral and steady
+	strength := ec.Ling + ec.ShiYi - 50
+	if strength < 0 {
+		strength = -strength
+	}
+
+	slot.ScoreStrength = 100 - strength
+	if ec.Di {
+		slot.ScoreStrength += 10
+	}
+
+	slot.ScoreStrength = clampScore(slot.ScoreStrength)
+
+	if ec.Ling >= 50 {
+		_reason(reasonLing)
+	} else {
+		_reason(reasonNoLing)
+	}
+
+	if ec.Shi >= 10 {
+		_reason(reasonShi)
+	} else {
+		_reason(reasonNoShi)
+	}
+
+	if ec.Di {
+		_reason(reasonDi)
+	} else {
+		_reason(reasonNoDi)
+	}
+
+	if ec.Stretch {
+		_reason(reasonStrong)
+	} else {
+		_reason(reasonWeak)
+	}
+
+	if len(missing) > 0 {
+		for _, m := range missing {
+			_reason(reasonMissing, m)
+		}
+	} else {
+		_reason(reasonComplete)
+	}
+
+	_reason(reasonLike, slot.Favourable.LikeString)
+}
+
+// SearchAuspicious : Rank every shichen in time range by chart, and fitness of name if given
+func SearchAuspicious(language int, from, to int64, loc utils.Location, opts Options, name *Name, limit int) *AuspiciousResult {
+	var (
+		ret      = &AuspiciousResult{}
+		segments []*calendar.PillarSegment
+	)
+
+	if to < from {
+		from, to = to, from
+	}
+
+	if to-from > AuspiciousMaxRange {
+		to = from + AuspiciousMaxRange
+	}
+
+	if limit <= 0 {
+		limit = AuspiciousDefaultLimit
+	}
+
+	ret.From, ret.To = from, to
+	for start := from; start < to; start += calendar.PillarWindowMax {
+		end := start + calendar.PillarWindowMax
+		if end > to {
+			end = to
+		}
+
+		for _, seg := range calendar.PillarSegments(start, end, loc, opts.Calendar) {
+			last := len(segments) - 1
+			if last >= 0 && segments[last].To == seg.From &&
+				segments[last].Year == seg.Year && segments[last].Month == seg.Month &&
+				segments[last].Day == seg.Day && segments[last].Hour == seg.Hour {
+				// Split by window, not by pillars
+				segments[last].To = seg.To
+				continue
+			}
+
+			if seg.To > seg.From {
+				segments = append(segments, seg)
+			}
+		}
+	}
+
+	for _, seg := range segments {
+		mid := (seg.From + seg.To) / 2
+		chart := NewChart(language, mid, loc, opts)
+		slot := &AuspiciousSlot{
+			From:            seg.From,
+			To:              seg.To,
+			YearString:      seg.Year.String(language),
+			MonthString:     seg.Month.String(language),
+			DayString:       seg.Day.String(language),
+			HourString:      seg.Hour.String(language),
+			EightCharacters: chart.EightCharacters,
+			FiveElements:    chart.GanzhiFiveElements.FiveElementsTotal,
+			Favourable:      chart.Favourable,
+		}
+
+		slot.scoreSlot(language)
+		if name != nil {
+			r := rankChart(language, name, chart, opts)
+			if r.Illegal {
+				return nil
+			}
+
+			slot.ScoreName = r.Rank.RankTotal
+			slot.Score = (slot.ScoreBalance*35 + slot.ScoreStrength*35 + slot.ScoreName*30) / 100
+			slot.Reasons = append(slot.Reasons,
+				fmt.Sprintf(texts.GetAlias(texts.AliasAuspiciousReason, reasonName, language), slot.ScoreName))
+		} else {
+			slot.Score = (slot.ScoreBalance + slot.ScoreStrength) / 2
+		}
+
+		ret.Slots = append(ret.Slots, slot)
+	}
+
+	ret.Total = len(ret.Slots)
+	sort.SliceStable(ret.Slots, func(i, j int) bool {
+		return ret.Slots[i].Score > ret.Slots[j].Score
+	})
+
+	if len(ret.Slots) > limit {
+		ret.Slots = ret.Slots[:limit]
+	}
+
+	return ret
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
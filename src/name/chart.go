@@ -0,0 +1,225 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file chart.go
+ * @package name
+ */
+
+package name
+
+import (
+	"calendar"
+	"sync"
+	"texts"
+	"utils"
+)
+
+// ChartCacheSize : Max number of charts cached, cache is flushed when full
+const ChartCacheSize = 4096
+
+type chartKey struct {
+	language  int
+	birthTime int64
+	loc       utils.Location
+	opts      Options
+}
+
+var (
+	chartCache     = make(map[chartKey]*Chart)
+	chartCacheLock sync.RWMutex
+)
+
+// Chart : Analysis of birth chart, independent of name
+type Chart struct {
+	language           int
+	options            Options
+	Calendar           *calendar.Calendar `json:"calendar"`
+	EightCharacters    eightCharacters    `json:"eight_characters"`
+	PillarDetails      pillarDetails      `json:"pillar_details"`
+	GanzhiFiveElements ganzhiFiveElements `json:"ganzhi_five_elements"`
+	SoundFiveElements  soundFiveElements  `json:"sound_five_elements"`
+	Favourable         *Favourable        `json:"favourable"`
+	Animal             animal             `json:"animal"`
+	Luck               *Luck              `json:"luck,omitempty"`
+	HourUnknown        bool               `json:"hour_unknown"`
+	WeakConclusions    []string           `json:"weak_conclusions,omitempty"`
+}
+
+func (chart *Chart) calculateEightCharacters() {
+	chart.EightCharacters.Year = &chart.Calendar.Ganzhi.Year
+	chart.EightCharacters.Month = &chart.Calendar.Ganzhi.Month
+	chart.EightCharacters.Day = &chart.Calendar.Ganzhi.Day
+	chart.EightCharacters.Hour = &chart.Calendar.Ganzhi.Hour
+	if chart.HourUnknown {
+		chart.EightCharacters.Hour = nil
+	}
+
+	chart.EightCharacters.complete()
+	chart.PillarDetails.complete(&chart.EightCharacters, chart.language)
+}
+
+func (chart *Chart) calculateGanzhi() {
+	// Hour pillar of unknown hour is invalid (-1), no element counted
+	for _, p := range []utils.GanzhiPair{chart.Calendar.Ganzhi.Year,
+		chart.Calendar.Ganzhi.Month,
+		chart.Calendar.Ganzhi.Day,
+		chart.Calendar.Ganzhi.Hour} {
+		// TianGan
+		chart.GanzhiFiveElements.FiveElements.Add(utils.GanFiveElement(p.TianGan), 1)
+
+		// DiZhi & ZhiCang
+		chart.GanzhiFiveElements.FiveElements.Add(utils.ZhiFiveElement(p.DiZhi), 1)
+		for _, gan := range utils.ZhiHiddenGans(p.DiZhi) {
+			chart.GanzhiFiveElements.FiveElementsZhi.Add(utils.GanFiveElement(gan), 1)
+		}
+	}
+
+	// Total
+	chart.GanzhiFiveElements.FiveElementsTotal.Wood = chart.GanzhiFiveElements.FiveElements.Wood + chart.GanzhiFiveElements.FiveElementsZhi.Wood
+	chart.GanzhiFiveElements.FiveElementsTotal.Fire = chart.GanzhiFiveElements.FiveElements.Fire + chart.GanzhiFiveElements.FiveElementsZhi.Fire
+	chart.GanzhiFiveElements.FiveElementsTotal.Earth = chart.GanzhiFiveElements.FiveElements.Earth + chart.GanzhiFiveElements.FiveElementsZhi.Earth
+	chart.GanzhiFiveElements.FiveElementsTotal.Metal = chart.GanzhiFiveElements.FiveElements.Metal + chart.GanzhiFiveElements.FiveElementsZhi.Metal
+	chart.GanzhiFiveElements.FiveElementsTotal.Water = chart.GanzhiFiveElements.FiveElements.Water + chart.GanzhiFiveElements.FiveElementsZhi.Water
+
+	chart.calculateStrength()
+}
+
+func (chart *Chart) calculateFavourable() {
+	chart.Favourable = newFavourable(chart.options.Favourable,
+		&chart.EightCharacters,
+		chart.GanzhiFiveElements.FiveElementsWeighted,
+		chart.language)
+}
+
+func (chart *Chart) calculateLuck() {
+	year := chart.Calendar.ChinaTime.Year
+	chart.Luck = NewLuck(chart.language, chart.Calendar, chart.options.Gender, year, year+LuckAnnualDefault-1)
+}
+
+func (chart *Chart) calculateSounds() {
+	chart.SoundFiveElements.YearSound.id, chart.SoundFiveElements.YearSound.Name, chart.SoundFiveElements.YearSound.Description = GanzhiSoundAlias(chart.Calendar.Ganzhi.Year, chart.language)
+	chart.SoundFiveElements.MonthSound.id, chart.SoundFiveElements.MonthSound.Name, chart.SoundFiveElements.MonthSound.Description = GanzhiSoundAlias(chart.Calendar.Ganzhi.Month, chart.language)
+	chart.SoundFiveElements.DaySound.id, chart.SoundFiveElements.DaySound.Name, chart.SoundFiveElements.DaySound.Description = GanzhiSoundAlias(chart.Calendar.Ganzhi.Day, chart.language)
+	chart.SoundFiveElements.HourSound.id, chart.SoundFiveElements.HourSound.Name, chart.SoundFiveElements.HourSound.Description = GanzhiSoundAlias(chart.Calendar.Ganzhi.Hour, chart.language)
+}
+
+func (chart *Chart) calculateAnimal() {
+	chart.Animal.Radicals = getAnimalRadicals(chart.Calendar.Lunar.AnimalSign, chart.language)
+	chart.Animal.Years = texts.GetMessage(texts.MessageAnimalYear, chart.Calendar.Lunar.AnimalSign, chart.language)
+}
+
+// markWeakConclusions : Conclusions weaker because the birth hour is missing
+func (chart *Chart) markWeakConclusions() {
+	if !chart.HourUnknown {
+		return
+	}
+
+	chart.WeakConclusions = []string{
+		"eight_characters.shi",
+		"eight_characters.stretch",
+		"eight_characters.like",
+		"pillar_details",
+		"favourable",
+		"ganzhi_five_elements",
+		"sound_five_elements.hour_sound",
+	}
+
+	for _, pillar := range chart.Calendar.Ganzhi.Uncertain {
+		chart.WeakConclusions = append(chart.WeakConclusions, "calendar.ganzhi."+pillar)
+	}
+}
+
+// clone : Copy of chart with its own calendar, which input zone may be set on
+func (chart *Chart) clone() *Chart {
+	var (
+		ret = *chart
+		c   = *chart.Calendar
+	)
+
+	ret.Calendar = &c
+
+	return &ret
+}
+
+// NewChart : Analyse birth chart
+func NewChart(language int, birthTime int64, loc utils.Location, opts Options) *Chart {
+	chart := &Chart{
+		language:    language,
+		options:     opts,
+		Calendar:    calendar.NewWithOptions(birthTime, loc, opts.Calendar),
+		HourUnknown: opts.Calendar.HourUnknown,
+	}
+
+	chart.Calendar.Localize(language)
+	chart.calculateEightCharacters()
+	chart.calculateGanzhi()
+	chart.calculateFavourable()
+	chart.calculateSounds()
+	chart.calculateAnimal()
+	chart.calculateLuck()
+	chart.markWeakConclusions()
+
+	return chart
+}
+
+// GetChart : Birth chart from cache, analysed and cached if missing
+func GetChart(language int, birthTime int64, loc utils.Location, opts Options) *Chart {
+	var (
+		key = chartKey{
+			language:  language,
+			birthTime: birthTime,
+			loc:       loc,
+			opts:      opts,
+		}
+		chart *Chart
+		ok    bool
+	)
+
+	chartCacheLock.RLock()
+	chart, ok = chartCache[key]
+	chartCacheLock.RUnlock()
+	if ok {
+		return chart.clone()
+	}
+
+	chart = NewChart(language, birthTime, loc, opts)
+	chartCacheLock.Lock()
+	if len(chartCache) >= ChartCacheSize {
+		chartCache = make(map[chartKey]*Chart)
+	}
+
+	chartCache[key] = chart
+	chartCacheLock.Unlock()
+
+	return chart.clone()
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
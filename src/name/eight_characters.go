@@ -38,7 +38,7 @@ type eightCharacters struct {
 	Year      *utils.GanzhiPair `json:"year"`
 	Month     *utils.GanzhiPair `json:"month"`
 	Day       *utils.GanzhiPair `json:"day"`
-	Hour      *utils.GanzhiPair `json:"hour"`
+	Hour      *utils.GanzhiPair `json:"hour"` // nil if hour unknown
 	Ling      int               `json:"ling"`
 	Shi       int               `json:"shi"`
 	ShiYi     int               `json:"shi_yi"`
@@ -79,15 +79,21 @@ func (ec *eightCharacters) calculateShi() (int, int) {
 		ret, retYi int
 	)
 
-	for _, c := range []int{
+	elements := []int{
 		utils.GanFiveElement(ec.Year.TianGan),
 		utils.ZhiFiveElement(ec.Year.DiZhi),
 		utils.GanFiveElement(ec.Month.TianGan),
 		//utils.ZhiFiveElement(ec.Month.DiZhi),
 		utils.ZhiFiveElement(ec.Day.DiZhi),
-		utils.GanFiveElement(ec.Hour.TianGan),
-		utils.ZhiFiveElement(ec.Hour.DiZhi),
-	} {
+	}
+
+	if ec.Hour != nil {
+		elements = append(elements,
+			utils.GanFiveElement(ec.Hour.TianGan),
+			utils.ZhiFiveElement(ec.Hour.DiZhi))
+	}
+
+	for _, c := range elements {
 		//fmt.Println("Shi", c, feDay)
 		switch utils.CompareFiveElements(c, feDay) {
 		case utils.FiveElementEqual, utils.FiveElementBirth:
@@ -97,6 +103,9 @@ func (ec *eightCharacters) calculateShi() (int, int) {
 		}
 	}
 
+	// Three pillars (hour unknown), scale to six elements
+	retYi = retYi * 6 / len(elements)
+
 	//fmt.Println("ShiYi", utils.ZhiFiveElement(ec.Month.DiZhi), feDay)
 	switch utils.CompareFiveElements(utils.ZhiFiveElement(ec.Month.DiZhi), feDay) {
 	case utils.FiveElementEqual, utils.FiveElementBirth:
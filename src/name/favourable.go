@@ -0,0 +1,300 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file favourable.go
+ * @package name
+ */
+
+package name
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"texts"
+	"utils"
+)
+
+// Favourable-element (用神) strategies
+const (
+	// FavourableFuYi : 扶抑, support weak day master or drain strong one
+	FavourableFuYi = "fuyi"
+	// FavourableTiaoHou : 调候, warm charts born in winter and cool charts born in summer
+	FavourableTiaoHou = "tiaohou"
+	// FavourableTongGuan : 通关, bridge two strong elements in conflict
+	FavourableTongGuan = "tongguan"
+	// FavourableDefault : Strategy if none given
+	FavourableDefault = FavourableFuYi
+)
+
+// Reasons, index of texts.AliasFavourableReason
+const (
+	favourableReasonStrong = iota
+	favourableReasonWeak
+	favourableReasonSummer
+	favourableReasonWinter
+	favourableReasonMild
+	favourableReasonConflict
+	favourableReasonNoConflict
+	favourableReasonSummary
+)
+
+// Favourable : Favourable (用神 and 喜神) and unfavourable (忌神) elements of chart
+type Favourable struct {
+	language       int
+	Strategy       string             `json:"strategy"`
+	Like           int                `json:"like"`
+	LikeString     string             `json:"like_alias"`
+	Likes          []int              `json:"likes"`
+	LikesString    []string           `json:"likes_alias"`
+	Dislikes       []int              `json:"dislikes"`
+	DislikesString []string           `json:"dislikes_alias"`
+	Figures        map[string]float64 `json:"figures"`
+	Reasons        []string           `json:"reasons"`
+}
+
+// favourableStrategy : Algorithm deciding favourable elements
+type favourableStrategy interface {
+	favourable(ec *eightCharacters, weights utils.FiveElementsWeight, f *Favourable)
+}
+
+var favourableStrategies = map[string]favourableStrategy{
+	FavourableFuYi:     favourableFuYi{},
+	FavourableTiaoHou:  favourableTiaoHou{},
+	FavourableTongGuan: favourableTongGuan{},
+}
+
+// IsFavourableStrategy : If strategy of given name exists, empty for default
+func IsFavourableStrategy(name string) bool {
+	if name == "" {
+		return true
+	}
+
+	_, ok := favourableStrategies[name]
+
+	return ok
+}
+
+// ListFavourableStrategies : Names of all strategies
+func ListFavourableStrategies() []string {
+	var ret []string
+
+	for name := range favourableStrategies {
+		ret = append(ret, name)
+	}
+
+	sort.Strings(ret)
+
+	return ret
+}
+
+// shiftElement : Element n steps after fe in birth order (Wood->Fire->Earth->Metal->Water)
+func shiftElement(fe, n int) int {
+	return ((fe+n)%5 + 5) % 5
+}
+
+func (f *Favourable) reason(idx int, args ...interface{}) {
+	r := texts.GetAlias(texts.AliasFavourableReason, idx, f.language)
+	if len(args) > 0 {
+		r = fmt.Sprintf(r, args...)
+	}
+
+	f.Reasons = append(f.Reasons, r)
+}
+
+func (f *Favourable) set(like int, likes, dislikes []int) {
+	f.Like = like
+	f.Likes = likes
+	f.Dislikes = dislikes
+}
+
+func (f *Favourable) localize() {
+	var _join = func(fes []int) (string, []string) {
+		var ret []string
+
+		for _, fe := range fes {
+			ret = append(ret, texts.GetAlias(texts.AliasFiveElement, fe, f.language))
+		}
+
+		return strings.Join(ret, ""), ret
+	}
+
+	f.LikeString = texts.GetAlias(texts.AliasFiveElement, f.Like, f.language)
+	likes, likesString := _join(f.Likes)
+	dislikes, dislikesString := _join(f.Dislikes)
+	f.LikesString = likesString
+	f.DislikesString = dislikesString
+	f.reason(favourableReasonSummary, f.LikeString, likes, dislikes)
+}
+
+// favourableFuYi : Drain (食伤) and control (财官) strong day master, support (印比) weak one
+type favourableFuYi struct{}
+
+func (favourableFuYi) favourable(ec *eightCharacters, weights utils.FiveElementsWeight, f *Favourable) {
+	var (
+		self    = ec.Self
+		di      = 0.0
+		stretch = 0.0
+	)
+
+	if ec.Di {
+		di = 1
+	}
+
+	if ec.Stretch {
+		stretch = 1
+	}
+
+	f.Figures["ling"] = float64(ec.Ling)
+	f.Figures["shi"] = float64(ec.Shi)
+	f.Figures["shi_yi"] = float64(ec.ShiYi)
+	f.Figures["di"] = di
+	f.Figures["stretch"] = stretch
+
+	if ec.Stretch {
+		f.set(shiftElement(self, 2),
+			[]int{shiftElement(self, 2), shiftElement(self, 1), shiftElement(self, 3)},
+			[]int{self, shiftElement(self, -1)})
+		f.reason(favourableReasonStrong)
+	} else {
+		f.set(shiftElement(self, -1),
+			[]int{shiftElement(self, -1), self},
+			[]int{shiftElement(self, 1), shiftElement(self, 2), shiftElement(self, 3)})
+		f.reason(favourableReasonWeak)
+	}
+}
+
+// favourableTiaoHou : Water for hot chart born in summer, fire for cold chart born in winter, FuYi otherwise
+type favourableTiaoHou struct{}
+
+func (favourableTiaoHou) favourable(ec *eightCharacters, weights utils.FiveElementsWeight, f *Favourable) {
+	var (
+		month = texts.GetAlias(texts.AliasZhi, ec.Month.DiZhi, f.language)
+		fire  = weights.Fire
+		water = weights.Water
+	)
+
+	f.Figures["month_zhi"] = float64(ec.Month.DiZhi)
+	f.Figures["fire"] = fire
+	f.Figures["water"] = water
+
+	switch ec.Month.DiZhi {
+	case utils.ZhiSi, utils.ZhiWu, utils.ZhiWei:
+		if fire > water {
+			f.set(utils.ElementWater,
+				[]int{utils.ElementWater, utils.ElementMetal},
+				[]int{utils.ElementFire, utils.ElementWood})
+			f.reason(favourableReasonSummer, month)
+
+			return
+		}
+	case utils.ZhiHai, utils.ZhiZi, utils.ZhiChou:
+		if water > fire {
+			f.set(utils.ElementFire,
+				[]int{utils.ElementFire, utils.ElementWood},
+				[]int{utils.ElementWater, utils.ElementMetal})
+			f.reason(favourableReasonWinter, month)
+
+			return
+		}
+	}
+
+	f.reason(favourableReasonMild, month)
+	favourableFuYi{}.favourable(ec, weights, f)
+}
+
+// favourableTongGuan : Element born by the attacker and birthing the attacked for two strong elements in conflict, FuYi otherwise
+type favourableTongGuan struct{}
+
+// Weighted strength of both elements in conflict at least
+const tongGuanMinWeight = 2.0
+
+func (favourableTongGuan) favourable(ec *eightCharacters, weights utils.FiveElementsWeight, f *Favourable) {
+	var (
+		attacker = -1
+		strength = 0.0
+	)
+
+	for fe := utils.ElementWood; fe <= utils.ElementWater; fe++ {
+		// fe kills the element two steps after it
+		a, b := weights.Values()[fe], weights.Values()[shiftElement(fe, 2)]
+		if b < a {
+			a = b
+		}
+
+		if a >= tongGuanMinWeight && a > strength {
+			attacker, strength = fe, a
+		}
+	}
+
+	if attacker < 0 {
+		f.reason(favourableReasonNoConflict)
+		favourableFuYi{}.favourable(ec, weights, f)
+
+		return
+	}
+
+	var (
+		attacked = shiftElement(attacker, 2)
+		bridge   = shiftElement(attacker, 1)
+	)
+
+	f.Figures["attacker"] = weights.Values()[attacker]
+	f.Figures["attacked"] = weights.Values()[attacked]
+	f.Figures["bridge"] = weights.Values()[bridge]
+	f.set(bridge, []int{bridge}, []int{shiftElement(bridge, 3)})
+	f.reason(favourableReasonConflict,
+		texts.GetAlias(texts.AliasFiveElement, attacker, f.language), weights.Values()[attacker],
+		texts.GetAlias(texts.AliasFiveElement, attacked, f.language), weights.Values()[attacked],
+		texts.GetAlias(texts.AliasFiveElement, bridge, f.language))
+}
+
+// newFavourable : Favourable elements of chart by strategy, default strategy if unknown
+func newFavourable(strategy string, ec *eightCharacters, weights utils.FiveElementsWeight, language int) *Favourable {
+	s, ok := favourableStrategies[strategy]
+	if !ok {
+		strategy = FavourableDefault
+		s = favourableStrategies[strategy]
+	}
+
+	ret := &Favourable{
+		language: language,
+		Strategy: strategy,
+		Figures:  make(map[string]float64),
+	}
+
+	s.favourable(ec, weights, ret)
+	ret.localize()
+
+	return ret
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
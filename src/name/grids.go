@@ -0,0 +1,228 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file grids.go
+ * @package name
+ */
+
+package name
+
+import (
+	"sort"
+)
+
+// Five-grid (五格) calculation schools
+const (
+	// GridSchoolKumasaki : 熊崎式, middle name counted as given name (三字名)
+	GridSchoolKumasaki = "kumasaki"
+	// GridSchoolCompound : 复姓式, middle name (such as mother's surname) counted as compound surname
+	GridSchoolCompound = "compound"
+	// GridSchoolEdge : 首尾式, WaiGe by the first and the last characters of full name
+	GridSchoolEdge = "edge"
+	// GridSchoolDual : 双姓式, TianGe by father's surname, WaiGe by mother's surname and the last character of given name
+	GridSchoolDual = "dual"
+	// GridSchoolDefault : School if none given
+	GridSchoolDefault = GridSchoolKumasaki
+	// GridSchoolDualDefault : School if none given for name of dual surname
+	GridSchoolDualDefault = GridSchoolDual
+)
+
+// gridSchool : Five-grid formulas of school. Family name is the whole surname, father's and mother's of dual surname,
+// father is the length of father's surname in it (0 if not dual)
+type gridSchool interface {
+	grids(family, middle, given []int, father int) (int, int, int, int, int)
+}
+
+var gridSchools = map[string]gridSchool{
+	GridSchoolKumasaki: gridKumasaki{},
+	GridSchoolCompound: gridCompound{},
+	GridSchoolEdge:     gridEdge{},
+	GridSchoolDual:     gridDual{},
+}
+
+// IsGridSchool : If grid school of given name exists, empty for default
+func IsGridSchool(name string) bool {
+	if name == "" {
+		return true
+	}
+
+	_, ok := gridSchools[name]
+
+	return ok
+}
+
+// ListGridSchools : Names of all grid schools
+func ListGridSchools() []string {
+	var ret []string
+
+	for name := range gridSchools {
+		ret = append(ret, name)
+	}
+
+	sort.Strings(ret)
+
+	return ret
+}
+
+// getGridSchool : Grid school by name, default school (of dual surname or not) if unknown
+func getGridSchool(name string, dual bool) (string, gridSchool) {
+	s, ok := gridSchools[name]
+	if !ok {
+		name = GridSchoolDefault
+		if dual {
+			name = GridSchoolDualDefault
+		}
+
+		s = gridSchools[name]
+	}
+
+	return name, s
+}
+
+func sumStrokes(strokes []int) int {
+	var ret int
+
+	for _, s := range strokes {
+		ret += s
+	}
+
+	return ret
+}
+
+// jiaShu : 假数, 1 added for single character surname or given name
+func jiaShu(strokes []int) int {
+	if len(strokes) == 1 {
+		return 1
+	}
+
+	return 0
+}
+
+// gridsBase : TianGe / RenGe / DiGe / ZongGe shared by schools. TianGe = Σ surname + 假数,
+// RenGe = last character of surname + first character of given name, DiGe = Σ given name + 假数, ZongGe = Σ full name
+func gridsBase(family, given []int) (int, int, int, int) {
+	var (
+		tian, ren, di, zong int
+		lf                  = len(family)
+		lg                  = len(given)
+	)
+
+	if lf > 0 {
+		tian = sumStrokes(family) + jiaShu(family)
+		ren = family[lf-1]
+	}
+
+	if lg > 0 {
+		ren += given[0]
+		di = sumStrokes(given) + jiaShu(given)
+	}
+
+	zong = sumStrokes(family) + sumStrokes(given)
+
+	return tian, ren, di, zong
+}
+
+// gridKumasaki : 熊崎式, middle name joined to given name, WaiGe = ZongGe - RenGe + 假数 of surname and given name
+type gridKumasaki struct{}
+
+func (gridKumasaki) grids(family, middle, given []int, father int) (int, int, int, int, int) {
+	given = append(append([]int{}, middle...), given...)
+	tian, ren, di, zong := gridsBase(family, given)
+	wai := zong - ren + jiaShu(family) + jiaShu(given)
+
+	return tian, ren, di, zong, wai
+}
+
+// gridCompound : 复姓式, middle name joined to surname, WaiGe = ZongGe - RenGe + 假数 of surname and given name
+type gridCompound struct{}
+
+func (gridCompound) grids(family, middle, given []int, father int) (int, int, int, int, int) {
+	family = append(append([]int{}, family...), middle...)
+	tian, ren, di, zong := gridsBase(family, given)
+	wai := zong - ren + jiaShu(family) + jiaShu(given)
+
+	return tian, ren, di, zong, wai
+}
+
+// gridEdgeWai : WaiGe = first character of surname (假数 if single) + last character of given name (假数 if single)
+func gridEdgeWai(family, given []int) int {
+	var (
+		wai int
+		lf  = len(family)
+		lg  = len(given)
+	)
+
+	if lf == 1 {
+		wai = 1
+	} else if lf > 1 {
+		wai = family[0]
+	}
+
+	if lg == 1 {
+		wai++
+	} else if lg > 1 {
+		wai += given[lg-1]
+	}
+
+	return wai
+}
+
+// gridEdge : 首尾式, middle name joined to given name, WaiGe by gridEdgeWai
+type gridEdge struct{}
+
+func (gridEdge) grids(family, middle, given []int, father int) (int, int, int, int, int) {
+	given = append(append([]int{}, middle...), given...)
+	tian, ren, di, zong := gridsBase(family, given)
+
+	return tian, ren, di, zong, gridEdgeWai(family, given)
+}
+
+// gridDual : 双姓式, middle name joined to given name. TianGe = father's surname + 假数 of it,
+// WaiGe = mother's surname + last character of given name (假数 if single), as 首尾式 if not dual
+type gridDual struct{}
+
+func (gridDual) grids(family, middle, given []int, father int) (int, int, int, int, int) {
+	given = append(append([]int{}, middle...), given...)
+	tian, ren, di, zong := gridsBase(family, given)
+	if father <= 0 || father >= len(family) {
+		return tian, ren, di, zong, gridEdgeWai(family, given)
+	}
+
+	tian = sumStrokes(family[:father]) + jiaShu(family[:father])
+	wai := sumStrokes(family[father:]) + jiaShu(given)
+	if len(given) > 1 {
+		wai += given[len(given)-1]
+	}
+
+	return tian, ren, di, zong, wai
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -0,0 +1,75 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file grids_test.go
+ * @package name
+ */
+
+package name
+
+import (
+	"testing"
+)
+
+func TestGridDual(t *testing.T) {
+	var (
+		// 2+2 name such as 张李某某, father's surname of 1 character
+		family = []int{11, 7}
+		given  = []int{9, 8}
+		father = 1
+	)
+
+	tian, ren, di, zong, wai := gridDual{}.grids(family, nil, given, father)
+	if tian != 12 || ren != 16 || di != 17 || zong != 35 || wai != 15 {
+		t.Errorf("dual : got %d %d %d %d %d, want 12 16 17 35 15", tian, ren, di, zong, wai)
+	}
+
+	for name, s := range map[string]gridSchool{
+		GridSchoolKumasaki: gridKumasaki{},
+		GridSchoolCompound: gridCompound{},
+	} {
+		t2, _, _, _, w2 := s.grids(family, nil, given, father)
+		if t2 == tian || w2 == wai {
+			t.Errorf("dual : TianGe %d and WaiGe %d should differ from %s %d and %d", tian, wai, name, t2, w2)
+		}
+	}
+
+	// Not dual surname, as 首尾式
+	got := [5]int{}
+	want := [5]int{}
+	got[0], got[1], got[2], got[3], got[4] = gridDual{}.grids(family, nil, given, 0)
+	want[0], want[1], want[2], want[3], want[4] = gridEdge{}.grids(family, nil, given, 0)
+	if got != want {
+		t.Errorf("dual of single surname : got %v, want %v", got, want)
+	}
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
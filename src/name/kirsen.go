@@ -30,6 +30,291 @@
 
 package name
 
+import (
+	"errors"
+	"list"
+	"sort"
+	"unihan"
+	"utils"
+)
+
+const (
+	// KirsenDefaultLimit : Default number of kirsen candidates
+	KirsenDefaultLimit = 20
+	// KirsenMaxLimit : Max number of kirsen candidates
+	KirsenMaxLimit = 100
+
+	// Characters kept for each stroke count in the pool
+	kirsenCharactersPerStroke = 3
+	// Pool is extended with common L2 below this size
+	kirsenMinPool = 64
+)
+
+type kirsenCharacter struct {
+	r         rune
+	stroke    int
+	favoured  bool
+	frequency int
+}
+
+type kirsenCombo struct {
+	strokes []int
+	score   int
+}
+
+// kirsenStroke : Stroke of character by stroke policy, simplified or traditionalized as calculateFiveRules does
+func kirsenStroke(r rune, policy string) int {
+	ns := &nameSpec{Runes: []rune{r}}
+	ns.assignUnihan()
+	if len(ns.Characters) == 0 || ns.Characters[0] == nil {
+		return 0
+	}
+
+	if policy == unihan.StrokePolicyModern {
+		ns.Characters = ns.simplify()
+	} else {
+		ns.Characters = ns.traditionalized()
+	}
+
+	strokes, _ := ns.queryStrokes(policy)
+	if len(strokes) == 0 {
+		return 0
+	}
+
+	return strokes[0]
+}
+
+// kirsenGoodRule : If the 81-rule of grid value is Ji or DaJi
+func kirsenGoodRule(i int) bool {
+	i = rule81Index(i)
+	if i <= 0 || i >= len(rule81Ranks) {
+		return false
+	}
+
+	return rule81Ranks[i] == RankJi || rule81Ranks[i] == RankDaJi
+}
+
+// kirsenLike : Favourable five-element of the birth chart
+func kirsenLike(language int, birthTime int64, loc utils.Location, opts Options) int {
+	// Cached chart is reused by ranking of candidates
+	return GetChart(language, birthTime, loc, opts).Favourable.Like
+}
+
+// kirsenPool : Pick common characters of the favourable element (and the element births it), grouped by stroke
+func kirsenPool(like int, exclude map[rune]bool, policy string) map[int][]*kirsenCharacter {
+	var (
+		pool  = make(map[int][]*kirsenCharacter)
+		total int
+	)
+
+	_fill := func(runes []rune) {
+		for i, r := range runes {
+			if exclude[r] {
+				continue
+			}
+
+			fe := list.QueryFiveElement(r)
+			if fe == utils.ElementUnknown {
+				continue
+			}
+
+			favoured := fe == like
+			if !favoured && utils.CompareFiveElements(fe, like) != utils.FiveElementBirth {
+				continue
+			}
+
+			stroke := kirsenStroke(r, policy)
+			if stroke <= 0 || len(pool[stroke]) >= kirsenCharactersPerStroke*2 {
+				continue
+			}
+
+			exclude[r] = true
+			pool[stroke] = append(pool[stroke], &kirsenCharacter{
+				r:         r,
+				stroke:    stroke,
+				favoured:  favoured,
+				frequency: i,
+			})
+			total++
+		}
+	}
+
+	_fill(list.ListCommonL1())
+	if total < kirsenMinPool {
+		_fill(list.ListCommonL2())
+	}
+
+	// Favoured first, then frequency
+	for stroke := range pool {
+		chars := pool[stroke]
+		sort.SliceStable(chars, func(i, j int) bool {
+			if chars[i].favoured != chars[j].favoured {
+				return chars[i].favoured
+			}
+
+			return chars[i].frequency < chars[j].frequency
+		})
+
+		if len(chars) > kirsenCharactersPerStroke {
+			pool[stroke] = chars[:kirsenCharactersPerStroke]
+		}
+	}
+
+	return pool
+}
+
+// kirsenCombos : Stroke combinations of given name whose RenGe / DiGe / ZongGe are Ji or DaJi
+func kirsenCombos(school gridSchool, family []int, father int, prefix []int, pool map[int][]*kirsenCharacter) []*kirsenCombo {
+	var (
+		strokes []int
+		ret     []*kirsenCombo
+		scores  = []int{0, 0, 25, 50, 75, 100}
+	)
+
+	for stroke := range pool {
+		strokes = append(strokes, stroke)
+	}
+
+	sort.Ints(strokes)
+
+	_check := func(given []int) {
+		_, ren, di, zong, _ := school.grids(family, nil, given, father)
+		if !kirsenGoodRule(ren) || !kirsenGoodRule(di) || !kirsenGoodRule(zong) {
+			return
+		}
+
+		ret = append(ret, &kirsenCombo{
+			strokes: given,
+			score: scores[rule81Ranks[rule81Index(ren)]] +
+				scores[rule81Ranks[rule81Index(di)]] +
+				scores[rule81Ranks[rule81Index(zong)]],
+		})
+	}
+
+	if len(prefix) > 0 {
+		for _, s := range strokes {
+			given := append(append([]int{}, prefix...), s)
+			_check(given)
+		}
+	} else {
+		for _, s1 := range strokes {
+			for _, s2 := range strokes {
+				_check([]int{s1, s2})
+			}
+		}
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].score > ret[j].score
+	})
+
+	return ret
+}
+
+// Kirsen : Generate given names for family name (and fixed prefix of given name) with birth time.
+// Mother's surname makes dual surname (父姓+母姓) of family name, such as four-character names
+func Kirsen(language int, family, mother, prefix []rune, birthTime int64, loc utils.Location, limit int, opts Options) ([]*RankData, error) {
+	var (
+		exclude    = make(map[rune]bool)
+		like       int
+		pool       map[int][]*kirsenCharacter
+		combos     []*kirsenCombo
+		familyName *Name
+		prefixName *Name
+		candidates [][]rune
+		ret        []*RankData
+	)
+
+	if len(family) == 0 {
+		return nil, errors.New("Family name required")
+	}
+
+	if limit <= 0 {
+		limit = KirsenDefaultLimit
+	}
+
+	if limit > KirsenMaxLimit {
+		limit = KirsenMaxLimit
+	}
+
+	for _, r := range family {
+		exclude[r] = true
+	}
+
+	for _, r := range mother {
+		exclude[r] = true
+	}
+
+	for _, r := range prefix {
+		exclude[r] = true
+	}
+
+	familyName = NewNameDual(family, mother, nil, nil)
+	familyName.Normalize()
+	prefixName = NewNameRunes(nil, nil, prefix)
+	prefixName.Normalize()
+	if familyName.Traditional.FamilyName.Len != len(family)+len(mother) ||
+		prefixName.Traditional.GivenName.Len != len(prefix) {
+		return nil, errors.New("Unknown characters in name")
+	}
+
+	if len(mother) > 0 && !familyName.ValidDualSurname() {
+		return nil, ErrInvalidDualSurname
+	}
+
+	like = kirsenLike(language, birthTime, loc, opts)
+	pool = kirsenPool(like, exclude, opts.Stroke)
+	familyDef := familyName.strokeDef(opts.Stroke)
+	familyStrokes, _ := familyDef.FamilyName.queryStrokes(opts.Stroke)
+	prefixStrokes, _ := prefixName.strokeDef(opts.Stroke).GivenName.queryStrokes(opts.Stroke)
+	_, school := getGridSchool(opts.Grid, familyDef.DualSurname)
+	combos = kirsenCombos(school, familyStrokes, familyDef.fatherSurnameLen(), prefixStrokes, pool)
+
+	// Three times of limit, some of them may be illegal
+	for _, combo := range combos {
+		if len(candidates) >= limit*3 {
+			break
+		}
+
+		if len(prefix) > 0 {
+			for _, c := range pool[combo.strokes[len(prefix)]] {
+				candidates = append(candidates, append(append([]rune{}, prefix...), c.r))
+			}
+		} else {
+			for _, c1 := range pool[combo.strokes[0]] {
+				for _, c2 := range pool[combo.strokes[1]] {
+					if c1.r == c2.r || (!c1.favoured && !c2.favoured) {
+						continue
+					}
+
+					candidates = append(candidates, []rune{c1.r, c2.r})
+				}
+			}
+		}
+	}
+
+	for _, given := range candidates {
+		n := NewNameDual(family, mother, nil, given)
+		n.Normalize()
+		rank := Rank(language, n, birthTime, loc, opts)
+		if rank.Illegal {
+			continue
+		}
+
+		ret = append(ret, rank)
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Rank.RankTotal > ret[j].Rank.RankTotal
+	})
+
+	if len(ret) > limit {
+		ret = ret[:limit]
+	}
+
+	return ret, nil
+}
+
 /*
  * Local variables:
  * tab-width: 4
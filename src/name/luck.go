@@ -0,0 +1,188 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file luck.go
+ * @package name
+ */
+
+package name
+
+import (
+	"calendar"
+	"time"
+	"utils"
+)
+
+const (
+	// LuckPillarCount : Count of luck pillars (大运) listed
+	LuckPillarCount = 8
+	// LuckAnnualDefault : Count of annual pillars (流年) listed by default
+	LuckAnnualDefault = 10
+	// LuckAnnualMax : Max count of annual pillars listed
+	LuckAnnualMax = 120
+)
+
+// LuckPillar : Luck pillar (大运) of ten years
+type LuckPillar struct {
+	Index        int              `json:"index"`
+	Pillar       utils.GanzhiPair `json:"pillar"`
+	PillarString string           `json:"pillar_alias"`
+	StartAge     int              `json:"start_age"`
+	StartYear    int              `json:"start_year"`
+	EndYear      int              `json:"end_year"`
+	TenGod       *tenGod          `json:"ten_god"`
+}
+
+// AnnualPillar : Annual pillar (流年) of a year
+type AnnualPillar struct {
+	Year         int              `json:"year"`
+	Age          int              `json:"age"`
+	Pillar       utils.GanzhiPair `json:"pillar"`
+	PillarString string           `json:"pillar_alias"`
+	TenGod       *tenGod          `json:"ten_god"`
+	Luck         int              `json:"luck"` // Index of luck pillar, -1 before luck starts
+}
+
+// Luck : Luck pillars and annual pillars of chart
+type Luck struct {
+	Gender      int             `json:"gender"`
+	Forward     bool            `json:"forward"`
+	Solarterm   int64           `json:"solarterm"`
+	Days        float64         `json:"days"`
+	StartYears  int             `json:"start_years"`
+	StartMonths int             `json:"start_months"`
+	StartDays   int             `json:"start_days"`
+	StartTime   int64           `json:"start_time"`
+	Pillars     []*LuckPillar   `json:"pillars"`
+	Annuals     []*AnnualPillar `json:"annuals"`
+}
+
+// luckSolarterm : Nearest jie (节) after (forward) or before birth
+func luckSolarterm(t time.Time, forward bool) time.Time {
+	var (
+		year = t.Year()
+		ret  time.Time
+	)
+
+	for y := year - 1; y <= year+1; y++ {
+		solarterms := calendar.GetSolarterms(y)
+		// Jie on even index, from XiaoHan
+		for i := 0; i < len(solarterms); i += 2 {
+			s := solarterms[i]
+			if forward && s.After(t) && (ret.IsZero() || s.Before(ret)) {
+				ret = s
+			}
+
+			if !forward && !s.After(t) && (ret.IsZero() || s.After(ret)) {
+				ret = s
+			}
+		}
+	}
+
+	return ret
+}
+
+// NewLuck : Luck pillars of chart by gender, with annual pillars from <fromYear> to <toYear>
+func NewLuck(language int, c *calendar.Calendar, gender int, fromYear, toYear int) *Luck {
+	var (
+		birth = c.China()
+		g     = &c.Ganzhi
+		month = g.Month.Value()
+		day   = g.Day.TianGan
+		ret   = &Luck{Gender: gender}
+		step  = 1
+	)
+
+	if gender != utils.GenderMale && gender != utils.GenderFemale {
+		return nil
+	}
+
+	// Yang year of male, or yin year of female, goes forward
+	ret.Forward = (utils.GanYinYang(g.Year.TianGan) == utils.YinYangYang) == (gender == utils.GenderMale)
+	if !ret.Forward {
+		step = -1
+	}
+
+	solarterm := luckSolarterm(birth, ret.Forward)
+	ret.Solarterm = solarterm.Unix()
+	ret.Days = solarterm.Sub(birth).Hours() / 24
+	if ret.Days < 0 {
+		ret.Days = -ret.Days
+	}
+
+	// Three days as one year, one day as four months, one shichen as ten days
+	ret.StartYears = int(ret.Days / 3)
+	months := (ret.Days - float64(ret.StartYears*3)) * 4
+	ret.StartMonths = int(months)
+	ret.StartDays = int((months - float64(ret.StartMonths)) * 30)
+	start := birth.AddDate(ret.StartYears, ret.StartMonths, ret.StartDays)
+	ret.StartTime = start.Unix()
+
+	for i := 0; i < LuckPillarCount; i++ {
+		p := &LuckPillar{
+			Index:     i,
+			Pillar:    *utils.ParseGanzhi(((month+step*(i+1))%60 + 60) % 60),
+			StartAge:  ret.StartYears + i*10,
+			StartYear: start.Year() + i*10,
+			EndYear:   start.Year() + i*10 + 9,
+		}
+		p.PillarString = p.Pillar.String(language)
+		p.TenGod = getTenGod(utils.CompareGan(day, p.Pillar.TianGan), language)
+		ret.Pillars = append(ret.Pillars, p)
+	}
+
+	if toYear-fromYear >= LuckAnnualMax {
+		toYear = fromYear + LuckAnnualMax - 1
+	}
+
+	for year := fromYear; year <= toYear; year++ {
+		a := &AnnualPillar{
+			Year:   year,
+			Age:    year - birth.Year(),
+			Pillar: *utils.ParseGanzhi(((year-4)%60 + 60) % 60),
+			Luck:   -1,
+		}
+		a.PillarString = a.Pillar.String(language)
+		a.TenGod = getTenGod(utils.CompareGan(day, a.Pillar.TianGan), language)
+		if year >= start.Year() {
+			a.Luck = (year - start.Year()) / 10
+			if a.Luck >= LuckPillarCount {
+				a.Luck = -1
+			}
+		}
+
+		ret.Annuals = append(ret.Annuals, a)
+	}
+
+	return ret
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
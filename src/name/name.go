@@ -31,6 +31,7 @@
 package name
 
 import (
+	"errors"
 	"fmt"
 	"list"
 	"regexp"
@@ -53,10 +54,16 @@ type nameDef struct {
 	MiddleName  nameSpec `json:"middle_name,omitempty"`
 	GivenName   nameSpec `json:"given_name"`
 	FullNameStr string   `json:"full_name"`
+	// Dual surname (父姓+母姓) in family name, rather than compound one
+	DualSurname bool     `json:"dual_surname,omitempty"`
+	Surnames    []string `json:"surnames,omitempty"`
 }
 
 // Name : Name defination
 type Name struct {
+	// Length of father's surname in dual surname, 0 if not dual
+	fatherSurnameLen int
+
 	Original    nameDef  `json:"original,omitempty"`
 	Simplified  nameDef  `json:"simplified,omitempty"`
 	Traditional nameDef  `json:"traditional,omitempty"`
@@ -90,6 +97,66 @@ func NewNameRunes(familyName, middleName, givenName []rune) *Name {
 	return name
 }
 
+// NewNameDual : Create name with dual surname of father's and mother's, as NewNameRunes if no mother's surname
+func NewNameDual(fatherSurname, motherSurname, middleName, givenName []rune) *Name {
+	familyName := append(append([]rune{}, fatherSurname...), motherSurname...)
+	name := NewNameRunes(familyName, middleName, givenName)
+	if len(fatherSurname) > 0 && len(motherSurname) > 0 {
+		name.fatherSurnameLen = len(fatherSurname)
+	}
+
+	return name
+}
+
+// assignSurnames : Split family name into father's and mother's surnames of dual surname
+func (nd *nameDef) assignSurnames(father int) {
+	if father <= 0 || father >= nd.FamilyName.Len {
+		return
+	}
+
+	nd.DualSurname = true
+	nd.Surnames = []string{
+		string(nd.FamilyName.Runes[:father]),
+		string(nd.FamilyName.Runes[father:]),
+	}
+}
+
+// fatherSurnameLen : Length of father's surname of dual surname, 0 if not dual
+func (nd *nameDef) fatherSurnameLen() int {
+	if !nd.DualSurname {
+		return 0
+	}
+
+	return len([]rune(nd.Surnames[0]))
+}
+
+// strokeDef : Name definition strokes counted on, simplified for modern strokes and traditional otherwise
+func (name *Name) strokeDef(policy string) *nameDef {
+	if policy == unihan.StrokePolicyModern {
+		return &name.Simplified
+	}
+
+	return &name.Traditional
+}
+
+// ErrInvalidDualSurname : Either part of dual surname is not a real surname
+var ErrInvalidDualSurname = errors.New("Invalid dual surname")
+
+// ValidDualSurname : If both parts of dual surname are real surnames (BaiJiaXing or compound surnames)
+func (name *Name) ValidDualSurname() bool {
+	if !name.Simplified.DualSurname {
+		return false
+	}
+
+	for _, s := range name.Simplified.Surnames {
+		if compound, _ := list.QueryCompoundSurname(s); !compound && list.QueryBaiJiaXing(s) == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
 // assignUnihan : Assign unihan characters of name specifications
 func (ns *nameSpec) assignUnihan() {
 	var (
@@ -121,16 +188,35 @@ func (ns *nameSpec) assignSpec() {
 	for _, c = range ns.Characters {
 		if c != nil {
 			ns.Runes = append(ns.Runes, c.Unicode)
-			ns.Strokes = append(ns.Strokes, c.QueryStrokePrefer())
 			fe = list.QueryFiveElement(c.Unicode)
 			ns.FiveElements = append(ns.FiveElements, fe)
 		}
 	}
 
+	// Derivations of the strokes grids use are in RankData
+	ns.Strokes, _ = ns.queryStrokes(unihan.StrokePolicyDefault)
 	ns.Len = len(ns.Runes)
 	ns.Str = string(ns.Runes)
 }
 
+// queryStrokes : Strokes of characters by stroke policy, with derivations
+func (ns *nameSpec) queryStrokes(policy string) ([]int, []*unihan.StrokeDerivation) {
+	var (
+		strokes []int
+		details []*unihan.StrokeDerivation
+	)
+
+	for _, c := range ns.Characters {
+		if c != nil {
+			sd := c.QueryStrokePolicy(policy)
+			strokes = append(strokes, sd.Stroke)
+			details = append(details, sd)
+		}
+	}
+
+	return strokes, details
+}
+
 // simplified : Simplify name specifications
 func (ns *nameSpec) simplify() []*unihan.HanCharacter {
 	var (
@@ -196,6 +282,7 @@ func (name *Name) Normalize() {
 	name.Original.MiddleName.assignSpec()
 	name.Original.GivenName.assignSpec()
 	name.Original.FullNameStr = fmt.Sprintf("%s %s", name.Original.FamilyName.Str, name.Original.GivenName.Str)
+	name.Original.assignSurnames(name.fatherSurnameLen)
 
 	// Simplified
 	name.Simplified.FamilyName.Characters = name.Original.FamilyName.simplify()
@@ -205,6 +292,7 @@ func (name *Name) Normalize() {
 	name.Simplified.GivenName.Characters = name.Original.GivenName.simplify()
 	name.Simplified.GivenName.assignSpec()
 	name.Simplified.FullNameStr = fmt.Sprintf("%s %s", name.Simplified.FamilyName.Str, name.Simplified.GivenName.Str)
+	name.Simplified.assignSurnames(name.fatherSurnameLen)
 
 	// Traditional
 	name.Traditional.FamilyName.Characters = name.Original.FamilyName.traditionalized()
@@ -214,6 +302,7 @@ func (name *Name) Normalize() {
 	name.Traditional.GivenName.Characters = name.Original.GivenName.traditionalized()
 	name.Traditional.GivenName.assignSpec()
 	name.Traditional.FullNameStr = fmt.Sprintf("%s %s", name.Traditional.FamilyName.Str, name.Traditional.GivenName.Str)
+	name.Traditional.assignSurnames(name.fatherSurnameLen)
 
 	_stripTone := func(pinyin string) string {
 		var (
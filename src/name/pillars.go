@@ -0,0 +1,219 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file pillars.go
+ * @package name
+ */
+
+package name
+
+import (
+	"texts"
+	"utils"
+)
+
+// ShenSha (神煞)
+const (
+	shenShaTianYi = iota
+	shenShaWenChang
+	shenShaYiMa
+	shenShaTaoHua
+	shenShaHuaGai
+	shenShaLu
+	shenShaYangRen
+)
+
+var (
+	// Dizhi of TianYi by tiangan
+	tianYiZhis = [][]int{
+		{utils.ZhiChou, utils.ZhiWei}, // 甲
+		{utils.ZhiZi, utils.ZhiShen},  // 乙
+		{utils.ZhiHai, utils.ZhiYou},  // 丙
+		{utils.ZhiHai, utils.ZhiYou},  // 丁
+		{utils.ZhiChou, utils.ZhiWei}, // 戊
+		{utils.ZhiZi, utils.ZhiShen},  // 己
+		{utils.ZhiChou, utils.ZhiWei}, // 庚
+		{utils.ZhiYin, utils.ZhiWu},   // 辛
+		{utils.ZhiMao, utils.ZhiSi},   // 壬
+		{utils.ZhiMao, utils.ZhiSi},   // 癸
+	}
+
+	// Dizhi of WenChang by tiangan
+	wenChangZhis = []int{
+		utils.ZhiSi, utils.ZhiWu, utils.ZhiShen, utils.ZhiYou, utils.ZhiShen,
+		utils.ZhiYou, utils.ZhiHai, utils.ZhiZi, utils.ZhiYin, utils.ZhiMao,
+	}
+
+	// Dizhi of Lu by tiangan
+	luZhis = []int{
+		utils.ZhiYin, utils.ZhiMao, utils.ZhiSi, utils.ZhiWu, utils.ZhiSi,
+		utils.ZhiWu, utils.ZhiShen, utils.ZhiYou, utils.ZhiHai, utils.ZhiZi,
+	}
+
+	// Dizhi of YangRen by tiangan, yang tiangan only
+	yangRenZhis = []int{
+		utils.ZhiMao, -1, utils.ZhiWu, -1, utils.ZhiWu,
+		-1, utils.ZhiYou, -1, utils.ZhiZi, -1,
+	}
+
+	// Dizhi of YiMa, TaoHua and HuaGai by triple combination (三合) of dizhi, 申子辰 / 亥卯未 / 寅午戌 / 巳酉丑
+	yiMaZhis    = []int{utils.ZhiYin, utils.ZhiSi, utils.ZhiShen, utils.ZhiHai}
+	taoHuaZhis  = []int{utils.ZhiYou, utils.ZhiZi, utils.ZhiMao, utils.ZhiWu}
+	huaGaiZhis  = []int{utils.ZhiChen, utils.ZhiWei, utils.ZhiXu, utils.ZhiChou}
+	tripleIndex = []int{0, 3, 2, 1, 0, 3, 2, 1, 0, 3, 2, 1}
+)
+
+type hiddenStem struct {
+	Gan          int    `json:"gan"`
+	GanString    string `json:"gan_alias"`
+	TenGod       int    `json:"ten_god"`
+	TenGodString string `json:"ten_god_alias"`
+}
+
+type pillarDetail struct {
+	Pillar       *utils.GanzhiPair `json:"pillar"`
+	PillarString string            `json:"pillar_alias"`
+	TenGod       int               `json:"ten_god"` // -1 for day master
+	TenGodString string            `json:"ten_god_alias,omitempty"`
+	HiddenStems  []*hiddenStem     `json:"hidden_stems"`
+	Stage        int               `json:"stage"`
+	StageString  string            `json:"stage_alias"`
+	Kongwang     bool              `json:"kongwang"`
+	ShenSha      []string          `json:"shen_sha"`
+}
+
+type pillarDetails struct {
+	Year           *pillarDetail `json:"year"`
+	Month          *pillarDetail `json:"month"`
+	Day            *pillarDetail `json:"day"`
+	Hour           *pillarDetail `json:"hour"` // nil if hour unknown
+	Kongwang       []int         `json:"kongwang"`
+	KongwangString string        `json:"kongwang_alias"`
+}
+
+// shenShas : ShenSha of dizhi in chart, by day tiangan and by year and day dizhi
+func shenShas(ec *eightCharacters, zhi int) []int {
+	var (
+		day = ec.Day.TianGan
+		ret []int
+	)
+
+	if day < 0 || day > 9 || zhi < 0 || zhi > 11 ||
+		ec.Year.DiZhi < 0 || ec.Year.DiZhi > 11 || ec.Day.DiZhi < 0 || ec.Day.DiZhi > 11 {
+		// Out of cycle
+		return nil
+	}
+
+	for _, z := range tianYiZhis[day] {
+		if z == zhi {
+			ret = append(ret, shenShaTianYi)
+		}
+	}
+
+	if wenChangZhis[day] == zhi {
+		ret = append(ret, shenShaWenChang)
+	}
+
+	for i, zhis := range [][]int{yiMaZhis, taoHuaZhis, huaGaiZhis} {
+		if zhis[tripleIndex[ec.Year.DiZhi]] == zhi || zhis[tripleIndex[ec.Day.DiZhi]] == zhi {
+			ret = append(ret, shenShaYiMa+i)
+		}
+	}
+
+	if luZhis[day] == zhi {
+		ret = append(ret, shenShaLu)
+	}
+
+	if yangRenZhis[day] == zhi {
+		ret = append(ret, shenShaYangRen)
+	}
+
+	return ret
+}
+
+func newPillarDetail(ec *eightCharacters, pillar *utils.GanzhiPair, dayMaster bool, language int) *pillarDetail {
+	if pillar == nil {
+		return nil
+	}
+
+	var (
+		day = ec.Day.TianGan
+		ret = &pillarDetail{
+			Pillar:       pillar,
+			PillarString: pillar.String(language),
+			TenGod:       -1,
+			Stage:        utils.GanStage(day, pillar.DiZhi),
+			HiddenStems:  []*hiddenStem{},
+			ShenSha:      []string{},
+		}
+	)
+
+	if !dayMaster {
+		ret.TenGod = utils.CompareGan(day, pillar.TianGan)
+		ret.TenGodString = texts.GetAlias(texts.AliasTenGod, ret.TenGod, language)
+	}
+
+	ret.StageString = texts.GetAlias(texts.AliasTwelveStage, ret.Stage, language)
+	for _, gan := range utils.ZhiHiddenGans(pillar.DiZhi) {
+		tg := utils.CompareGan(day, gan)
+		ret.HiddenStems = append(ret.HiddenStems, &hiddenStem{
+			Gan:          gan,
+			GanString:    texts.GetAlias(texts.AliasGan, gan, language),
+			TenGod:       tg,
+			TenGodString: texts.GetAlias(texts.AliasTenGod, tg, language),
+		})
+	}
+
+	for _, s := range shenShas(ec, pillar.DiZhi) {
+		ret.ShenSha = append(ret.ShenSha, texts.GetAlias(texts.AliasShenSha, s, language))
+	}
+
+	return ret
+}
+
+func (pd *pillarDetails) complete(ec *eightCharacters, language int) {
+	pd.Year = newPillarDetail(ec, ec.Year, false, language)
+	pd.Month = newPillarDetail(ec, ec.Month, false, language)
+	pd.Day = newPillarDetail(ec, ec.Day, true, language)
+	pd.Hour = newPillarDetail(ec, ec.Hour, false, language)
+
+	// Kongwang by xun of day pillar
+	k1, k2 := ec.Day.Kongwang()
+	pd.Kongwang = []int{k1, k2}
+	pd.KongwangString = texts.GetAlias(texts.AliasZhi, k1, language) + texts.GetAlias(texts.AliasZhi, k2, language)
+	for _, d := range []*pillarDetail{pd.Year, pd.Month, pd.Hour} {
+		if d != nil && (d.Pillar.DiZhi == k1 || d.Pillar.DiZhi == k2) {
+			d.Kongwang = true
+		}
+	}
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -0,0 +1,83 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file pillars_test.go
+ * @package name
+ */
+
+package name
+
+import (
+	"reflect"
+	"testing"
+	"utils"
+)
+
+func TestShenShas(t *testing.T) {
+	var cases = []struct {
+		year   int
+		dayGan int
+		dayZhi int
+		zhi    int
+		want   []int
+	}{
+		// 甲 day, 子 year
+		{utils.ZhiZi, utils.GanJia, utils.ZhiZi, utils.ZhiChou, []int{shenShaTianYi}},
+		{utils.ZhiZi, utils.GanJia, utils.ZhiZi, utils.ZhiSi, []int{shenShaWenChang}},
+		{utils.ZhiZi, utils.GanJia, utils.ZhiZi, utils.ZhiYin, []int{shenShaYiMa, shenShaLu}},
+		{utils.ZhiZi, utils.GanJia, utils.ZhiZi, utils.ZhiYou, []int{shenShaTaoHua}},
+		{utils.ZhiZi, utils.GanJia, utils.ZhiZi, utils.ZhiChen, []int{shenShaHuaGai}},
+		{utils.ZhiZi, utils.GanJia, utils.ZhiZi, utils.ZhiMao, []int{shenShaYangRen}},
+		{utils.ZhiZi, utils.GanJia, utils.ZhiZi, utils.ZhiHai, nil},
+		// 辛 day of 卯, 午 year
+		{utils.ZhiWu, utils.GanXin, utils.ZhiMao, utils.ZhiWu, []int{shenShaTianYi}},
+		{utils.ZhiWu, utils.GanXin, utils.ZhiMao, utils.ZhiShen, []int{shenShaYiMa}},
+		{utils.ZhiWu, utils.GanXin, utils.ZhiMao, utils.ZhiSi, []int{shenShaYiMa}},
+		{utils.ZhiWu, utils.GanXin, utils.ZhiMao, utils.ZhiZi, []int{shenShaWenChang, shenShaTaoHua}},
+		{utils.ZhiWu, utils.GanXin, utils.ZhiMao, utils.ZhiYou, []int{shenShaLu}},
+		// Out of cycle
+		{-4, utils.GanJia, utils.ZhiZi, utils.ZhiChou, nil},
+		{utils.ZhiZi, -2, utils.ZhiZi, utils.ZhiChou, nil},
+	}
+
+	for _, c := range cases {
+		ec := &eightCharacters{
+			Year: &utils.GanzhiPair{TianGan: utils.GanJia, DiZhi: c.year},
+			Day:  &utils.GanzhiPair{TianGan: c.dayGan, DiZhi: c.dayZhi},
+		}
+
+		if got := shenShas(ec, c.zhi); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%d %d %d : got %v, want %v", c.dayGan, c.dayZhi, c.zhi, got, c.want)
+		}
+	}
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
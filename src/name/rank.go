@@ -33,11 +33,13 @@ package name
 import (
 	"calendar"
 	"dict"
+	"fmt"
 	"list"
 	"math"
 	"poetry"
 	"strings"
 	"texts"
+	"unihan"
 	"utils"
 )
 
@@ -49,6 +51,8 @@ type loc struct {
 */
 
 type fiveRules struct {
+	School string `json:"school"`
+
 	TianGe                       int     `json:"tian_ge"`
 	TianGeFiveElement            string  `json:"tian_ge_five_element"`
 	TianGeFiveElementDescription string  `json:"tian_ge_five_element_description"`
@@ -104,6 +108,10 @@ type ganzhiFiveElements struct {
 	FiveElements      utils.FiveElementsCount `json:"five_elements"`
 	FiveElementsZhi   utils.FiveElementsCount `json:"five_elements_zhi"`
 	FiveElementsTotal utils.FiveElementsCount `json:"five_elements_total"`
+	// Strength weighted by season, hidden stems and interactions
+	FiveElementsWeighted utils.FiveElementsWeight `json:"five_elements_weighted"`
+	Seasons              []string                 `json:"seasons"`
+	Interactions         []string                 `json:"interactions"`
 }
 
 type dictXinhua struct {
@@ -125,70 +133,82 @@ type rank struct {
 	RankDescription     string `json:"rank_description"`
 }
 
+// Options : Options of name ranking and kirsen
+type Options struct {
+	Calendar calendar.Options `json:"calendar"`
+	// Gender for luck pillars, no luck pillars if unknown
+	Gender int `json:"gender"`
+	// Favourable-element strategy, default strategy if empty
+	Favourable string `json:"favourable"`
+	// Stroke policy of five grids, default policy if empty
+	Stroke string `json:"stroke"`
+	// Five-grid calculation school, default school if empty
+	Grid string `json:"grid"`
+}
+
+// rankStrokes : Strokes of name by stroke policy
+type rankStrokes struct {
+	FamilyName        []int                      `json:"family_name"`
+	MiddleName        []int                      `json:"middle_name,omitempty"`
+	GivenName         []int                      `json:"given_name"`
+	FamilyNameDetails []*unihan.StrokeDerivation `json:"family_name_details"`
+	MiddleNameDetails []*unihan.StrokeDerivation `json:"middle_name_details,omitempty"`
+	GivenNameDetails  []*unihan.StrokeDerivation `json:"given_name_details"`
+}
+
 // RankData : struct of name ranking result
 type RankData struct {
-	language           int
-	Name               *Name              `json:"name"`
-	DictXinhua         dictXinhua         `json:"dict_xinhua"`
-	BaiJiaXing         *list.BaiJiaXing   `json:"bai_jia_xing,omitempty"`
-	Poetries           []*poetry.Poetry   `json:"poetries,omitempty"`
-	FiveRules          fiveRules          `json:"five_rules"`
-	EightCharacters    eightCharacters    `json:"eight_characters"`
-	Calendar           *calendar.Calendar `json:"calendar"`
-	GanzhiFiveElements ganzhiFiveElements `json:"ganzhi_five_elements"`
-	SoundFiveElements  soundFiveElements  `json:"sound_five_elements"`
-	Animal             animal             `json:"animal"`
-	Rank               rank               `json:"rank"`
-	Homonyms           []string           `json:"homonyms"`
-	Illegal            bool               `json:"illegal"`
+	language     int
+	options      Options
+	Name         *Name            `json:"name"`
+	DictXinhua   dictXinhua       `json:"dict_xinhua"`
+	BaiJiaXing   *list.BaiJiaXing `json:"bai_jia_xing,omitempty"`
+	Poetries     []*poetry.Poetry `json:"poetries,omitempty"`
+	StrokePolicy string           `json:"stroke_policy"`
+	Strokes      rankStrokes      `json:"strokes"`
+	FiveRules    fiveRules        `json:"five_rules"`
+	*Chart
+	Rank            rank         `json:"rank"`
+	Homonyms        []string     `json:"homonyms"`
+	Illegal         bool         `json:"illegal"`
+	WeakConclusions []string     `json:"weak_conclusions,omitempty"`
+	Window          *RankWindow  `json:"window,omitempty"`
+	Splits          []*NameSplit `json:"splits,omitempty"`
 }
 
-func (rank *RankData) calculateFiveRules() {
-	// Traditional name prefered
-	n := &rank.Name.Traditional
-	if n.FamilyName.Len >= 2 {
-		// hyphenated
-		rank.FiveRules.TianGe = n.FamilyName.Strokes[0] + n.FamilyName.Strokes[1]
-		if n.GivenName.Len >= 1 {
-			rank.FiveRules.RenGe = n.FamilyName.Strokes[1] + n.GivenName.Strokes[0]
-		} else {
-			rank.FiveRules.RenGe = n.FamilyName.Strokes[1]
-		}
-	} else if n.FamilyName.Len == 1 {
-		rank.FiveRules.TianGe = n.FamilyName.Strokes[0] + 1
-		if n.GivenName.Len >= 1 {
-			rank.FiveRules.RenGe = n.FamilyName.Strokes[0] + n.GivenName.Strokes[0]
-		} else {
-			rank.FiveRules.RenGe = n.FamilyName.Strokes[0]
-		}
+// rule81Index : Fold grid value into 1 - 81
+func rule81Index(i int) int {
+	if i > 81 {
+		return i - 80
 	}
 
-	if n.GivenName.Len >= 2 {
-		rank.FiveRules.DiGe = n.GivenName.Strokes[0] + n.GivenName.Strokes[1]
-	} else if n.GivenName.Len == 1 {
-		rank.FiveRules.DiGe = n.GivenName.Strokes[0] + 1
-	}
+	return i
+}
 
-	if n.FamilyName.Len >= 2 {
-		if n.GivenName.Len >= 2 {
-			rank.FiveRules.WaiGe = n.FamilyName.Strokes[0] + n.GivenName.Strokes[1]
-		} else if n.GivenName.Len == 1 {
-			rank.FiveRules.WaiGe = n.FamilyName.Strokes[0] + 1
-		}
-	} else if n.FamilyName.Len == 1 {
-		if n.GivenName.Len >= 2 {
-			rank.FiveRules.WaiGe = 1 + n.GivenName.Strokes[1]
-		} else if n.GivenName.Len == 1 {
-			rank.FiveRules.WaiGe = 2
-		}
+// calculateStrokes : Strokes of name by stroke policy of options, simplified name for modern strokes and traditional otherwise
+func (rank *RankData) calculateStrokes() {
+	rank.StrokePolicy = rank.options.Stroke
+	if !unihan.IsStrokePolicy(rank.StrokePolicy) || rank.StrokePolicy == "" {
+		rank.StrokePolicy = unihan.StrokePolicyDefault
 	}
 
-	for _, h := range n.FamilyName.Strokes {
-		rank.FiveRules.ZongGe = rank.FiveRules.ZongGe + h
-	}
-	for _, h := range n.GivenName.Strokes {
-		rank.FiveRules.ZongGe = rank.FiveRules.ZongGe + h
-	}
+	n := rank.Name.strokeDef(rank.StrokePolicy)
+
+	rank.Strokes.FamilyName, rank.Strokes.FamilyNameDetails = n.FamilyName.queryStrokes(rank.StrokePolicy)
+	rank.Strokes.MiddleName, rank.Strokes.MiddleNameDetails = n.MiddleName.queryStrokes(rank.StrokePolicy)
+	rank.Strokes.GivenName, rank.Strokes.GivenNameDetails = n.GivenName.queryStrokes(rank.StrokePolicy)
+}
+
+func (rank *RankData) calculateFiveRules() {
+	rank.calculateStrokes()
+	n := rank.Name.strokeDef(rank.StrokePolicy)
+	school, s := getGridSchool(rank.options.Grid, n.DualSurname)
+	rank.FiveRules.School = school
+	rank.FiveRules.TianGe,
+		rank.FiveRules.RenGe,
+		rank.FiveRules.DiGe,
+		rank.FiveRules.ZongGe,
+		rank.FiveRules.WaiGe = s.grids(rank.Strokes.FamilyName, rank.Strokes.MiddleName, rank.Strokes.GivenName, n.fatherSurnameLen())
 
 	_mod := func(i, m int) int {
 		r := i % m
@@ -224,18 +244,11 @@ func (rank *RankData) calculateFiveRules() {
 	rank.FiveRules.ZongGeGod = getTenGod(zg, rank.language)
 	rank.FiveRules.WaiGeGod = getTenGod(wg, rank.language)
 
-	_g81 := func(i int) int {
-		if i > 81 {
-			return i - 80
-		}
-		return i
-	}
-
-	rank.FiveRules.TianGeRule = getRule81(_g81(rank.FiveRules.TianGe), rank.language)
-	rank.FiveRules.DiGeRule = getRule81(_g81(rank.FiveRules.DiGe), rank.language)
-	rank.FiveRules.RenGeRule = getRule81(_g81(rank.FiveRules.RenGe), rank.language)
-	rank.FiveRules.ZongGeRule = getRule81(_g81(rank.FiveRules.ZongGe), rank.language)
-	rank.FiveRules.WaiGeRule = getRule81(_g81(rank.FiveRules.WaiGe), rank.language)
+	rank.FiveRules.TianGeRule = getRule81(rule81Index(rank.FiveRules.TianGe), rank.language)
+	rank.FiveRules.DiGeRule = getRule81(rule81Index(rank.FiveRules.DiGe), rank.language)
+	rank.FiveRules.RenGeRule = getRule81(rule81Index(rank.FiveRules.RenGe), rank.language)
+	rank.FiveRules.ZongGeRule = getRule81(rule81Index(rank.FiveRules.ZongGe), rank.language)
+	rank.FiveRules.WaiGeRule = getRule81(rule81Index(rank.FiveRules.WaiGe), rank.language)
 
 	rank.FiveRules.TianGeRuleRank = texts.GetAlias(texts.AliasRank, rank.FiveRules.TianGeRule.Rank, rank.language)
 	rank.FiveRules.DiGeRuleRank = texts.GetAlias(texts.AliasRank, rank.FiveRules.DiGeRule.Rank, rank.language)
@@ -244,116 +257,6 @@ func (rank *RankData) calculateFiveRules() {
 	rank.FiveRules.WaiGeRuleRank = texts.GetAlias(texts.AliasRank, rank.FiveRules.WaiGeRule.Rank, rank.language)
 }
 
-func (rank *RankData) calculateEightCharacters() {
-	rank.EightCharacters.Year = &rank.Calendar.Ganzhi.Year
-	rank.EightCharacters.Month = &rank.Calendar.Ganzhi.Month
-	rank.EightCharacters.Day = &rank.Calendar.Ganzhi.Day
-	rank.EightCharacters.Hour = &rank.Calendar.Ganzhi.Hour
-	rank.EightCharacters.complete()
-}
-
-func (rank *RankData) calculateGanzhi() {
-	var v int
-	// TianGan
-	for _, v = range []int{rank.Calendar.Ganzhi.Year.TianGan,
-		rank.Calendar.Ganzhi.Month.TianGan,
-		rank.Calendar.Ganzhi.Day.TianGan,
-		rank.Calendar.Ganzhi.Hour.TianGan} {
-		switch v {
-		case utils.GanJia, utils.GanYi:
-			rank.GanzhiFiveElements.FiveElements.Wood++
-		case utils.GanBing, utils.GanDing:
-			rank.GanzhiFiveElements.FiveElements.Fire++
-		case utils.GanWu, utils.GanJi:
-			rank.GanzhiFiveElements.FiveElements.Earth++
-		case utils.GanGeng, utils.GanXin:
-			rank.GanzhiFiveElements.FiveElements.Metal++
-		case utils.GanRen, utils.GanGui:
-			rank.GanzhiFiveElements.FiveElements.Water++
-		}
-	}
-
-	// DiZhi & ZhiCang
-	for _, v = range []int{rank.Calendar.Ganzhi.Year.DiZhi,
-		rank.Calendar.Ganzhi.Month.DiZhi,
-		rank.Calendar.Ganzhi.Day.DiZhi,
-		rank.Calendar.Ganzhi.Hour.DiZhi} {
-		switch v {
-		case utils.ZhiZi:
-			rank.GanzhiFiveElements.FiveElements.Water++
-			rank.GanzhiFiveElements.FiveElementsZhi.Water++ // 癸
-		case utils.ZhiChou:
-			rank.GanzhiFiveElements.FiveElements.Earth++
-			rank.GanzhiFiveElements.FiveElementsZhi.Earth++ // 己
-			rank.GanzhiFiveElements.FiveElementsZhi.Metal++ // 辛
-			rank.GanzhiFiveElements.FiveElementsZhi.Water++ // 癸
-		case utils.ZhiYin:
-			rank.GanzhiFiveElements.FiveElements.Wood++
-			rank.GanzhiFiveElements.FiveElementsZhi.Wood++  // 甲
-			rank.GanzhiFiveElements.FiveElementsZhi.Fire++  // 丙
-			rank.GanzhiFiveElements.FiveElementsZhi.Earth++ // 戊
-		case utils.ZhiMao:
-			rank.GanzhiFiveElements.FiveElements.Wood++
-			rank.GanzhiFiveElements.FiveElementsZhi.Wood++ // 乙
-		case utils.ZhiChen:
-			rank.GanzhiFiveElements.FiveElements.Earth++
-			rank.GanzhiFiveElements.FiveElementsZhi.Earth++ // 戊
-			rank.GanzhiFiveElements.FiveElementsZhi.Water++ // 癸
-			rank.GanzhiFiveElements.FiveElementsZhi.Wood++  // 乙
-		case utils.ZhiSi:
-			rank.GanzhiFiveElements.FiveElements.Fire++
-			rank.GanzhiFiveElements.FiveElementsZhi.Fire++  // 丙
-			rank.GanzhiFiveElements.FiveElementsZhi.Earth++ // 戊
-			rank.GanzhiFiveElements.FiveElementsZhi.Metal++ // 庚
-		case utils.ZhiWu:
-			rank.GanzhiFiveElements.FiveElements.Fire++
-			rank.GanzhiFiveElements.FiveElementsZhi.Fire++  // 丁
-			rank.GanzhiFiveElements.FiveElementsZhi.Earth++ // 己
-		case utils.ZhiWei:
-			rank.GanzhiFiveElements.FiveElements.Earth++
-			rank.GanzhiFiveElements.FiveElementsZhi.Earth++ // 己
-			rank.GanzhiFiveElements.FiveElementsZhi.Wood++  // 乙
-			rank.GanzhiFiveElements.FiveElementsZhi.Fire++  // 丁
-		case utils.ZhiXu:
-			rank.GanzhiFiveElements.FiveElements.Metal++
-			rank.GanzhiFiveElements.FiveElementsZhi.Metal++ // 庚
-			rank.GanzhiFiveElements.FiveElementsZhi.Water++ // 壬
-			rank.GanzhiFiveElements.FiveElementsZhi.Earth++ // 戊
-		case utils.ZhiYou:
-			rank.GanzhiFiveElements.FiveElements.Metal++
-			rank.GanzhiFiveElements.FiveElementsZhi.Metal++ // 辛
-		case utils.ZhiShen:
-			rank.GanzhiFiveElements.FiveElements.Earth++
-			rank.GanzhiFiveElements.FiveElementsZhi.Earth++ // 戊
-			rank.GanzhiFiveElements.FiveElementsZhi.Metal++ // 辛
-			rank.GanzhiFiveElements.FiveElementsZhi.Fire++  // 丁
-		case utils.ZhiHai:
-			rank.GanzhiFiveElements.FiveElements.Water++
-			rank.GanzhiFiveElements.FiveElementsZhi.Water++ // 壬
-			rank.GanzhiFiveElements.FiveElementsZhi.Wood++  // 甲
-		}
-	}
-
-	// Total
-	rank.GanzhiFiveElements.FiveElementsTotal.Wood = rank.GanzhiFiveElements.FiveElements.Wood + rank.GanzhiFiveElements.FiveElementsZhi.Wood
-	rank.GanzhiFiveElements.FiveElementsTotal.Fire = rank.GanzhiFiveElements.FiveElements.Fire + rank.GanzhiFiveElements.FiveElementsZhi.Fire
-	rank.GanzhiFiveElements.FiveElementsTotal.Earth = rank.GanzhiFiveElements.FiveElements.Earth + rank.GanzhiFiveElements.FiveElementsZhi.Earth
-	rank.GanzhiFiveElements.FiveElementsTotal.Metal = rank.GanzhiFiveElements.FiveElements.Metal + rank.GanzhiFiveElements.FiveElementsZhi.Metal
-	rank.GanzhiFiveElements.FiveElementsTotal.Water = rank.GanzhiFiveElements.FiveElements.Water + rank.GanzhiFiveElements.FiveElementsZhi.Water
-}
-
-func (rank *RankData) calculateSounds() {
-	rank.SoundFiveElements.YearSound.id, rank.SoundFiveElements.YearSound.Name, rank.SoundFiveElements.YearSound.Description = GanzhiSoundAlias(rank.Calendar.Ganzhi.Year, rank.language)
-	rank.SoundFiveElements.MonthSound.id, rank.SoundFiveElements.MonthSound.Name, rank.SoundFiveElements.MonthSound.Description = GanzhiSoundAlias(rank.Calendar.Ganzhi.Month, rank.language)
-	rank.SoundFiveElements.DaySound.id, rank.SoundFiveElements.DaySound.Name, rank.SoundFiveElements.DaySound.Description = GanzhiSoundAlias(rank.Calendar.Ganzhi.Day, rank.language)
-	rank.SoundFiveElements.HourSound.id, rank.SoundFiveElements.HourSound.Name, rank.SoundFiveElements.HourSound.Description = GanzhiSoundAlias(rank.Calendar.Ganzhi.Hour, rank.language)
-}
-
-func (rank *RankData) calculateAnimal() {
-	rank.Animal.Radicals = getAnimalRadicals(rank.Calendar.Lunar.AnimalSign, rank.language)
-	rank.Animal.Years = texts.GetMessage(texts.MessageAnimalYear, rank.Calendar.Lunar.AnimalSign, rank.language)
-}
-
 func (rank *RankData) calculateRankFiveRules() {
 	scores := []int{0, 0, 25, 50, 75, 100}
 
@@ -386,11 +289,137 @@ func (rank *RankData) calculateRankFiveRules() {
 }
 
 func (rank *RankData) calculateRankEightElements() {
+	var (
+		fes                       []int
+		fe                        int
+		count, minCount, maxCount float64
+		total                     float64
+		scoreElements             int
+		scoreEight                int
+		counts                    = rank.GanzhiFiveElements.FiveElementsWeighted.Values()
+	)
+
+	// Given name (and middle name) carries the five elements
+	fes = append(fes, rank.Name.Original.MiddleName.FiveElements...)
+	fes = append(fes, rank.Name.Original.GivenName.FiveElements...)
+	if len(fes) == 0 {
+		return
+	}
+
+	minCount, maxCount = counts[0], counts[0]
+	for _, count = range counts {
+		total += count
+		if count < minCount {
+			minCount = count
+		}
+
+		if count > maxCount {
+			maxCount = count
+		}
+	}
+
+	for _, fe = range fes {
+		if fe < utils.ElementWood || fe > utils.ElementWater {
+			// Unknown, neutral
+			scoreElements += 50
+			scoreEight += 50
+			continue
+		}
+
+		// Deficits of the chart
+		count = counts[fe]
+		switch {
+		case count < weightMissing:
+			scoreElements += 100
+		case count == minCount:
+			scoreElements += 80
+		case count == maxCount:
+			scoreElements += 20
+		case count*5 <= total:
+			scoreElements += 60
+		default:
+			scoreElements += 40
+		}
+
+		// Favourable element
+		switch utils.CompareFiveElements(fe, rank.Favourable.Like) {
+		case utils.FiveElementEqual:
+			scoreEight += 100
+		case utils.FiveElementBirth:
+			scoreEight += 80
+		case utils.FiveElementKilled:
+			scoreEight += 50
+		case utils.FiveElementBirthed:
+			scoreEight += 30
+		case utils.FiveElementKill:
+			scoreEight += 10
+		}
+	}
+
+	rank.Rank.RankFiveElements = scoreElements / len(fes)
+	rank.Rank.RankEightCharacters = scoreEight / len(fes)
+}
+
+func (rank *RankData) calculateRankTotal() {
+	var (
+		level                 int
+		weightFiveRules       = 0.4
+		weightFiveElements    = 0.3
+		weightEightCharacters = 0.3
+	)
+
+	if rank.HourUnknown {
+		// Chart of three pillars is less reliable
+		weightFiveRules, weightFiveElements, weightEightCharacters = 0.5, 0.25, 0.25
+	}
+
+	rank.Rank.RankTotal = int(math.Round(
+		float64(rank.Rank.RankFiveRules)*weightFiveRules +
+			float64(rank.Rank.RankFiveElements)*weightFiveElements +
+			float64(rank.Rank.RankEightCharacters)*weightEightCharacters))
+	if rank.Rank.RankTotal > 100 {
+		rank.Rank.RankTotal = 100
+	}
+
+	switch {
+	case rank.Rank.RankTotal >= 90:
+		level = RankDaJi
+	case rank.Rank.RankTotal >= 75:
+		level = RankJi
+	case rank.Rank.RankTotal >= 60:
+		level = RankBanJi
+	case rank.Rank.RankTotal >= 40:
+		level = RankXiong
+	default:
+		level = RankDaXiong
+	}
+
+	rank.Rank.RankDescription = fmt.Sprintf(texts.GetAlias(texts.AliasRankDescription, 0, rank.language),
+		rank.Rank.RankTotal,
+		texts.GetAlias(texts.AliasRank, level, rank.language),
+		rank.Rank.RankFiveRules,
+		rank.Rank.RankFiveElements,
+		rank.Rank.RankEightCharacters,
+		rank.Favourable.LikeString)
+}
+
+// markWeakConclusions : Conclusions weaker because the birth hour is missing
+func (rank *RankData) markWeakConclusions() {
+	if !rank.HourUnknown {
+		return
+	}
+
+	rank.WeakConclusions = append([]string{}, rank.Chart.WeakConclusions...)
+	rank.WeakConclusions = append(rank.WeakConclusions,
+		"rank.rank_five_elements",
+		"rank.rank_eight_characters")
 }
 
 func (rank *RankData) calculateRanks() {
 	rank.calculateRankFiveRules()
 	rank.calculateRankEightElements()
+	rank.calculateRankTotal()
+	rank.markWeakConclusions()
 }
 
 func (rank *RankData) queryXinhua() {
@@ -413,7 +442,12 @@ func (rank *RankData) queryDictionaries() {
 }
 
 func (rank *RankData) queryBaiJiaXing() {
-	rank.BaiJiaXing = list.QueryBaiJiaXing(rank.Name.Simplified.FamilyName.Str)
+	if rank.Name.Simplified.DualSurname {
+		// Father's surname
+		rank.BaiJiaXing = list.QueryBaiJiaXing(rank.Name.Simplified.Surnames[0])
+	} else {
+		rank.BaiJiaXing = list.QueryBaiJiaXing(rank.Name.Simplified.FamilyName.Str)
+	}
 }
 
 func (rank *RankData) queryPoetry() {
@@ -437,9 +471,20 @@ func groupPinyin(pinyin []string) [][]string {
 }
 
 // Rank : Rank name with birth time
-func Rank(language int, name *Name, birthTime int64, loc utils.Location) *RankData {
+func Rank(language int, name *Name, birthTime int64, loc utils.Location, opts Options) *RankData {
+	// Chart shared by all names of the same birth
+	return rankChart(language, name, GetChart(language, birthTime, loc, opts), opts)
+}
+
+// rankChart : Rank name with given birth chart, not cached ones of passing birth times
+func rankChart(language int, name *Name, chart *Chart, opts Options) *RankData {
 	var (
-		rank        = &RankData{language: language, Name: name, Illegal: false}
+		rank = &RankData{
+			language: language,
+			options:  opts,
+			Name:     name,
+			Illegal:  false,
+		}
 		pinyinGroup [][]string
 		pinyin      string
 		sensitives  []string
@@ -462,18 +507,9 @@ func Rank(language int, name *Name, birthTime int64, loc utils.Location) *RankDa
 		}
 	}
 
-	rank.Calendar = calendar.New(birthTime, loc)
-	rank.Calendar.Ganzhi.YearString = rank.Calendar.Ganzhi.Year.String(rank.language)
-	rank.Calendar.Ganzhi.MonthString = rank.Calendar.Ganzhi.Month.String(rank.language)
-	rank.Calendar.Ganzhi.DayString = rank.Calendar.Ganzhi.Day.String(rank.language)
-	rank.Calendar.Ganzhi.HourString = rank.Calendar.Ganzhi.Hour.String(rank.language)
+	rank.Chart = chart
 
-	// For Chinese, ignore middle name now
 	rank.calculateFiveRules()
-	rank.calculateEightCharacters()
-	rank.calculateGanzhi()
-	rank.calculateSounds()
-	rank.calculateAnimal()
 	rank.queryDictionaries()
 	rank.queryBaiJiaXing()
 	rank.queryPoetry()
@@ -0,0 +1,181 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file split.go
+ * @package name
+ */
+
+package name
+
+import (
+	"list"
+	"sort"
+	"strings"
+	"unihan"
+)
+
+// Longest surname, such as 爱新觉罗
+const splitMaxFamilyLength = 4
+
+// Scores of surname and given name length
+const (
+	splitScoreExplicit       = 100
+	splitScoreCompoundCommon = 70
+	splitScoreCompoundRare   = 55
+	splitScoreSingleKnown    = 50
+	splitScoreSingleUnknown  = 10
+	splitScoreGivenShort     = 30
+	splitScoreGivenLong      = 10
+)
+
+// Separators between surname and given name in full name, surname first
+var splitSeparators = []string{" ", "　"}
+
+// Separators of transliterated full name (such as 约翰·史密斯), surname last
+var splitSeparatorsTransliterated = []string{"·", "•", "・"}
+
+// NameSplit : Split of full name into surname and given name
+type NameSplit struct {
+	FamilyName string `json:"family_name"`
+	GivenName  string `json:"given_name"`
+	Compound   bool   `json:"compound"`
+	Known      bool   `json:"known"`
+	Score      int    `json:"score"`
+}
+
+// simplifiedString : Simplified form of runes, as they are if unknown
+func simplifiedString(runes []rune) string {
+	var ret []rune
+
+	for _, r := range runes {
+		c, _ := unihan.Query(r)
+		if c != nil {
+			if s, _ := c.QuerySimplifiedPrefer(); s != 0 {
+				r = s
+			}
+		}
+
+		ret = append(ret, r)
+	}
+
+	return string(ret)
+}
+
+func newNameSplit(family, given []rune) *NameSplit {
+	ret := &NameSplit{
+		FamilyName: string(family),
+		GivenName:  string(given),
+	}
+
+	if len(family) > 1 {
+		ret.Known, _ = list.QueryCompoundSurname(simplifiedString(family))
+		ret.Compound = true
+	} else {
+		ret.Known = list.QueryBaiJiaXing(simplifiedString(family)) != nil
+	}
+
+	return ret
+}
+
+// explicitSplit : Split by given surname and given name parts, nil if either empty
+func explicitSplit(family, given, sep string) []*NameSplit {
+	f := []rune(strings.TrimSpace(family))
+	g := []rune(strings.Replace(strings.TrimSpace(given), sep, "", -1))
+	if len(f) == 0 || len(g) == 0 {
+		return nil
+	}
+
+	split := newNameSplit(f, g)
+	split.Score = splitScoreExplicit
+
+	return []*NameSplit{split}
+}
+
+// SplitFullName : Split full name into surname and given name, by separator if given (surname last if transliterated),
+// or by BaiJiaXing and compound surnames. Alternatives of ambiguous name (such as 万俟 and 万) ranked by score
+func SplitFullName(full string) []*NameSplit {
+	var (
+		runes []rune
+		ret   []*NameSplit
+	)
+
+	full = strings.TrimSpace(full)
+	for _, sep := range splitSeparatorsTransliterated {
+		if i := strings.LastIndex(full, sep); i >= 0 {
+			return explicitSplit(full[i+len(sep):], full[:i], sep)
+		}
+	}
+
+	for _, sep := range splitSeparators {
+		parts := strings.SplitN(full, sep, 2)
+		if len(parts) == 2 {
+			return explicitSplit(parts[0], parts[1], sep)
+		}
+	}
+
+	runes = []rune(full)
+	for l := 1; l < len(runes) && l <= splitMaxFamilyLength; l++ {
+		split := newNameSplit(runes[:l], runes[l:])
+		if split.Compound {
+			if !split.Known {
+				continue
+			}
+
+			_, common := list.QueryCompoundSurname(simplifiedString(runes[:l]))
+			if common {
+				split.Score = splitScoreCompoundCommon
+			} else {
+				split.Score = splitScoreCompoundRare
+			}
+		} else if split.Known {
+			split.Score = splitScoreSingleKnown
+		} else {
+			split.Score = splitScoreSingleUnknown
+		}
+
+		switch len(runes) - l {
+		case 1, 2:
+			split.Score += splitScoreGivenShort
+		case 3:
+			split.Score += splitScoreGivenLong
+		}
+
+		ret = append(ret, split)
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Score > ret[j].Score
+	})
+
+	return ret
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
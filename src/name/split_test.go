@@ -0,0 +1,73 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file split_test.go
+ * @package name
+ */
+
+package name
+
+import (
+	"testing"
+)
+
+func TestSplitFullNameSeparator(t *testing.T) {
+	var cases = []struct {
+		full   string
+		family string
+		given  string
+	}{
+		{"张 三丰", "张", "三丰"},
+		{"欧阳　修", "欧阳", "修"},
+		{"约翰·史密斯", "史密斯", "约翰"},
+		{"约翰·菲茨杰拉德·肯尼迪", "肯尼迪", "约翰菲茨杰拉德"},
+		{"阿依古丽・买买提", "买买提", "阿依古丽"},
+		{"·史密斯", "", ""},
+		{"约翰·", "", ""},
+	}
+
+	for _, c := range cases {
+		splits := SplitFullName(c.full)
+		if c.family == "" {
+			if splits != nil {
+				t.Errorf("%s : got %v, want nil", c.full, splits)
+			}
+
+			continue
+		}
+
+		if len(splits) != 1 || splits[0].FamilyName != c.family || splits[0].GivenName != c.given {
+			t.Errorf("%s : got %v, want %s %s", c.full, splits, c.family, c.given)
+		}
+	}
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
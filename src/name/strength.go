@@ -0,0 +1,275 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file strength.go
+ * @package name
+ */
+
+package name
+
+import (
+	"fmt"
+	"math"
+	"texts"
+	"utils"
+)
+
+// Seasonal states (旺相休囚死) of element in month
+const (
+	seasonWang = iota
+	seasonXiang
+	seasonXiu
+	seasonQiu
+	seasonSi
+)
+
+// Interactions, index of texts.AliasInteraction
+const (
+	interactionStemTransform = iota
+	interactionStemCombine
+	interactionZhiCombine
+	interactionZhiClash
+	interactionZhiPunish
+	interactionZhiHarm
+)
+
+const (
+	// Weight of stems bound by combination (合绊) without transformation
+	stemCombineWeight = 0.8
+	// Weight of clashed (冲) dizhi
+	zhiClashWeight = 0.7
+	// Weight of punished (刑) dizhi
+	zhiPunishWeight = 0.9
+	// Weight of harmed (害) dizhi
+	zhiHarmWeight = 0.9
+	// Strength added to element of six combination (六合)
+	zhiCombineBonus = 0.5
+	// Weighted strength below it counts as missing
+	weightMissing = 0.5
+)
+
+var (
+	// Weights of Wang / Xiang / Xiu / Qiu / Si
+	seasonWeights = []float64{1.5, 1.2, 1.0, 0.8, 0.6}
+
+	// Weights of hidden stems (primary, middle, residual) by count
+	hiddenStemWeights = [][]float64{
+		{1.0},
+		{0.7, 0.3},
+		{0.6, 0.3, 0.1},
+	}
+
+	// Element of six combination (六合) by smaller dizhi of the pair, 子丑 / 寅亥 / 卯戌 / 辰酉 / 巳申 / 午未
+	zhiCombineElements = map[int]int{
+		utils.ZhiZi:   utils.ElementEarth,
+		utils.ZhiYin:  utils.ElementWood,
+		utils.ZhiMao:  utils.ElementFire,
+		utils.ZhiChen: utils.ElementMetal,
+		utils.ZhiSi:   utils.ElementWater,
+		utils.ZhiWu:   utils.ElementEarth,
+	}
+)
+
+// seasonState : State of element in season of month element
+func seasonState(fe, monthFE int) int {
+	switch utils.CompareFiveElements(fe, monthFE) {
+	case utils.FiveElementEqual:
+		return seasonWang
+	case utils.FiveElementBirthed:
+		return seasonXiang
+	case utils.FiveElementBirth:
+		return seasonXiu
+	case utils.FiveElementKill:
+		return seasonQiu
+	}
+
+	return seasonSi
+}
+
+// zhiPunished : If two dizhi punish (刑) each other, 子卯 / 寅巳申 / 丑戌未 / 辰午酉亥 by themselves
+func zhiPunished(a, b int) bool {
+	if a > b {
+		a, b = b, a
+	}
+
+	switch {
+	case a == utils.ZhiZi && b == utils.ZhiMao:
+		return true
+	case a != b && isIn(a, utils.ZhiYin, utils.ZhiSi, utils.ZhiShen) && isIn(b, utils.ZhiYin, utils.ZhiSi, utils.ZhiShen):
+		return true
+	case a != b && isIn(a, utils.ZhiChou, utils.ZhiWei, utils.ZhiXu) && isIn(b, utils.ZhiChou, utils.ZhiWei, utils.ZhiXu):
+		return true
+	case a == b && isIn(a, utils.ZhiChen, utils.ZhiWu, utils.ZhiYou, utils.ZhiHai):
+		return true
+	}
+
+	return false
+}
+
+func isIn(v int, values ...int) bool {
+	for _, x := range values {
+		if v == x {
+			return true
+		}
+	}
+
+	return false
+}
+
+// calculateStrength : Weighted five-element strength by season, hidden stem weights and interactions of pillars
+func (chart *Chart) calculateStrength() {
+	var (
+		pillars   []utils.GanzhiPair
+		stemFEs   []int
+		stemWs    []float64
+		zhiWs     []float64
+		weighted  utils.FiveElementsWeight
+		monthFE   = utils.ZhiFiveElement(chart.Calendar.Ganzhi.Month.DiZhi)
+		g         = &chart.GanzhiFiveElements
+		_interact = func(idx int, args ...interface{}) {
+			g.Interactions = append(g.Interactions,
+				fmt.Sprintf(texts.GetAlias(texts.AliasInteraction, idx, chart.language), args...))
+		}
+		_gan = func(gan int) string {
+			return texts.GetAlias(texts.AliasGan, gan, chart.language)
+		}
+		_zhi = func(zhi int) string {
+			return texts.GetAlias(texts.AliasZhi, zhi, chart.language)
+		}
+		_fe = func(fe int) string {
+			return texts.GetAlias(texts.AliasFiveElement, fe, chart.language)
+		}
+		_add = func(fe int, w float64) {
+			weighted.Add(fe, w*seasonWeights[seasonState(fe, monthFE)])
+		}
+	)
+
+	for _, p := range []utils.GanzhiPair{chart.Calendar.Ganzhi.Year,
+		chart.Calendar.Ganzhi.Month,
+		chart.Calendar.Ganzhi.Day,
+		chart.Calendar.Ganzhi.Hour} {
+		// Hour pillar of unknown hour is invalid
+		if p.TianGan < 0 || p.DiZhi < 0 {
+			continue
+		}
+
+		pillars = append(pillars, p)
+		stemFEs = append(stemFEs, utils.GanFiveElement(p.TianGan))
+		stemWs = append(stemWs, 1.0)
+		zhiWs = append(zhiWs, 1.0)
+	}
+
+	g.Seasons = nil
+	for fe := utils.ElementWood; fe <= utils.ElementWater; fe++ {
+		g.Seasons = append(g.Seasons, texts.GetAlias(texts.AliasSeasonState, seasonState(fe, monthFE), chart.language))
+	}
+
+	// Five combinations (五合) of adjacent stems, transformed if element of combination rules the month
+	g.Interactions = []string{}
+	for i := 0; i+1 < len(pillars); i++ {
+		a, b := pillars[i].TianGan, pillars[i+1].TianGan
+		if a-b != 5 && b-a != 5 {
+			continue
+		}
+
+		if b < a {
+			a, b = b, a
+		}
+
+		// 甲己土 / 乙庚金 / 丙辛水 / 丁壬木 / 戊癸火
+		fe := (a + 2) % 5
+		if fe == monthFE {
+			stemFEs[i], stemFEs[i+1] = fe, fe
+			_interact(interactionStemTransform, _gan(a), _gan(b), _fe(fe))
+		} else {
+			stemWs[i] *= stemCombineWeight
+			stemWs[i+1] *= stemCombineWeight
+			_interact(interactionStemCombine, _gan(a), _gan(b))
+		}
+	}
+
+	// Combinations, clashes, punishments and harms of dizhi
+	for i := 0; i < len(pillars); i++ {
+		for j := i + 1; j < len(pillars); j++ {
+			a, b := pillars[i].DiZhi, pillars[j].DiZhi
+			if (a+b)%12 == 1 {
+				lo := a
+				if b < lo {
+					lo = b
+				}
+
+				fe := zhiCombineElements[lo]
+				_add(fe, zhiCombineBonus)
+				_interact(interactionZhiCombine, _zhi(a), _zhi(b), _fe(fe))
+			}
+
+			if (a-b+12)%12 == 6 {
+				zhiWs[i] *= zhiClashWeight
+				zhiWs[j] *= zhiClashWeight
+				_interact(interactionZhiClash, _zhi(a), _zhi(b))
+			}
+
+			if zhiPunished(a, b) {
+				zhiWs[i] *= zhiPunishWeight
+				zhiWs[j] *= zhiPunishWeight
+				_interact(interactionZhiPunish, _zhi(a), _zhi(b))
+			}
+
+			if (a+b)%12 == 7 {
+				zhiWs[i] *= zhiHarmWeight
+				zhiWs[j] *= zhiHarmWeight
+				_interact(interactionZhiHarm, _zhi(a), _zhi(b))
+			}
+		}
+	}
+
+	for i, p := range pillars {
+		_add(stemFEs[i], stemWs[i])
+		hidden := utils.ZhiHiddenGans(p.DiZhi)
+		for k, gan := range hidden {
+			_add(utils.GanFiveElement(gan), zhiWs[i]*hiddenStemWeights[len(hidden)-1][k])
+		}
+	}
+
+	_round := func(v float64) float64 {
+		return math.Round(v*100) / 100
+	}
+
+	g.FiveElementsWeighted = utils.FiveElementsWeight{
+		Wood:  _round(weighted.Wood),
+		Fire:  _round(weighted.Fire),
+		Earth: _round(weighted.Earth),
+		Metal: _round(weighted.Metal),
+		Water: _round(weighted.Water),
+	}
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -0,0 +1,148 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file window.go
+ * @package name
+ */
+
+package name
+
+import (
+	"calendar"
+	"utils"
+)
+
+type chartSegment struct {
+	calendar.PillarSegment
+	YearString  string `json:"year_alias"`
+	MonthString string `json:"month_alias"`
+	DayString   string `json:"day_alias"`
+	HourString  string `json:"hour_alias"`
+	Like        int    `json:"like"`
+	LikeString  string `json:"like_alias"`
+}
+
+type windowSegment struct {
+	chartSegment
+	Rank rank `json:"rank"`
+}
+
+// ChartWindow : Favourable element of every distinct pillars in birth time window
+type ChartWindow struct {
+	From        int64           `json:"from"`
+	To          int64           `json:"to"`
+	Segments    []*chartSegment `json:"segments"`
+	LikeChanged bool            `json:"like_changed"`
+}
+
+// RankWindow : Name rank of every distinct pillars in birth time window
+type RankWindow struct {
+	From        int64            `json:"from"`
+	To          int64            `json:"to"`
+	Segments    []*windowSegment `json:"segments"`
+	RankMin     int              `json:"rank_min"`
+	RankMax     int              `json:"rank_max"`
+	LikeChanged bool             `json:"like_changed"`
+	Robust      bool             `json:"robust"`
+}
+
+func newChartSegment(language int, seg *calendar.PillarSegment, f *Favourable) chartSegment {
+	return chartSegment{
+		PillarSegment: *seg,
+		YearString:    seg.Year.String(language),
+		MonthString:   seg.Month.String(language),
+		DayString:     seg.Day.String(language),
+		HourString:    seg.Hour.String(language),
+		Like:          f.Like,
+		LikeString:    f.LikeString,
+	}
+}
+
+// NewChartWindow : Favourable element at each distinct pillars in time window [from, to]
+func NewChartWindow(language int, from, to int64, loc utils.Location, opts Options) *ChartWindow {
+	ret := &ChartWindow{From: from, To: to}
+
+	for i, seg := range calendar.PillarSegments(from, to, loc, opts.Calendar) {
+		// Middle of segment stands for it, charts of passing times not cached
+		chart := NewChart(language, (seg.From+seg.To)/2, loc, opts)
+		cs := newChartSegment(language, seg, chart.Favourable)
+		if i > 0 && cs.Like != ret.Segments[0].Like {
+			ret.LikeChanged = true
+		}
+
+		ret.Segments = append(ret.Segments, &cs)
+	}
+
+	return ret
+}
+
+// NewRankWindow : Rank name at each distinct pillars in time window [from, to]
+func NewRankWindow(language int, name *Name, from, to int64, loc utils.Location, opts Options) *RankWindow {
+	ret := &RankWindow{From: from, To: to}
+
+	for i, seg := range calendar.PillarSegments(from, to, loc, opts.Calendar) {
+		// Middle of segment stands for it, charts of passing times not cached
+		r := rankChart(language, name, NewChart(language, (seg.From+seg.To)/2, loc, opts), opts)
+		if r.Illegal {
+			return nil
+		}
+
+		ws := &windowSegment{
+			chartSegment: newChartSegment(language, seg, r.Favourable),
+			Rank:         r.Rank,
+		}
+
+		if i == 0 {
+			ret.RankMin, ret.RankMax = r.Rank.RankTotal, r.Rank.RankTotal
+		} else {
+			if ws.Like != ret.Segments[0].Like {
+				ret.LikeChanged = true
+			}
+
+			if r.Rank.RankTotal < ret.RankMin {
+				ret.RankMin = r.Rank.RankTotal
+			}
+
+			if r.Rank.RankTotal > ret.RankMax {
+				ret.RankMax = r.Rank.RankTotal
+			}
+		}
+
+		ret.Segments = append(ret.Segments, ws)
+	}
+
+	// Favourable element stays, and rank varies less than a level
+	ret.Robust = !ret.LikeChanged && ret.RankMax-ret.RankMin < 15
+
+	return ret
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -0,0 +1,74 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file window_test.go
+ * @package name
+ */
+
+package name
+
+import (
+	"testing"
+	"time"
+	"utils"
+)
+
+func TestNewChartWindow(t *testing.T) {
+	var (
+		loc    = utils.Location{Latitude: 39.9, Longitude: 116.4}
+		china  = time.FixedZone("CST", 8*3600)
+		lichun = time.Date(2024, time.February, 4, 16, 27, 0, 0, china).Unix()
+		from   = lichun - 3600
+		to     = lichun + 3600
+	)
+
+	w := NewChartWindow(0, from, to, loc, Options{})
+	if len(w.Segments) < 2 {
+		t.Fatalf("window across 立春 : got %d segments, want 2 at least", len(w.Segments))
+	}
+
+	if w.Segments[0].From != from || w.Segments[len(w.Segments)-1].To != to {
+		t.Errorf("window : segments cover %d - %d, want %d - %d",
+			w.Segments[0].From, w.Segments[len(w.Segments)-1].To, from, to)
+	}
+
+	for i := 1; i < len(w.Segments); i++ {
+		if w.Segments[i].From != w.Segments[i-1].To {
+			t.Errorf("segment %d : starts at %d, previous ends at %d", i, w.Segments[i].From, w.Segments[i-1].To)
+		}
+	}
+
+	if w.Segments[0].Year == w.Segments[len(w.Segments)-1].Year {
+		t.Errorf("window across 立春 : year pillar should change")
+	}
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -63,6 +63,36 @@ const (
 	AliasSolarterm
 	// AliasSoundFiveElement : 15
 	AliasSoundFiveElement
+	// AliasRankDescription : 16
+	AliasRankDescription
+	// AliasOfficer : 17
+	AliasOfficer
+	// AliasMansion : 18
+	AliasMansion
+	// AliasDirection : 19
+	AliasDirection
+	// AliasPengZuGan : 20
+	AliasPengZuGan
+	// AliasPengZuZhi : 21
+	AliasPengZuZhi
+	// AliasFetusPosition : 22
+	AliasFetusPosition
+	// AliasClashDescription : 23
+	AliasClashDescription
+	// AliasAuspiciousReason : 24
+	AliasAuspiciousReason
+	// AliasTwelveStage : 25
+	AliasTwelveStage
+	// AliasShenSha : 26
+	AliasShenSha
+	// AliasFavourableReason : 27
+	AliasFavourableReason
+	// AliasSeasonState : 28
+	AliasSeasonState
+	// AliasInteraction : 29
+	AliasInteraction
+	// AliasICSSummary : 30
+	AliasICSSummary
 )
 
 // Aliases
@@ -161,6 +191,132 @@ var (
 			"桑柘木", "大溪水", "砂中土", "天上火", "石榴木", "大海水",
 		},
 	}
+	officerAliases = [][]string{
+		{"建", "除", "满", "平", "定", "执", "破", "危", "成", "收", "开", "闭"},
+		{"建", "除", "滿", "平", "定", "執", "破", "危", "成", "收", "開", "閉"},
+	}
+	mansionAliases = [][]string{
+		{
+			"角木蛟", "亢金龙", "氐土貉", "房日兔", "心月狐", "尾火虎", "箕水豹",
+			"斗木獬", "牛金牛", "女土蝠", "虚日鼠", "危月燕", "室火猪", "壁水貐",
+			"奎木狼", "娄金狗", "胃土雉", "昴日鸡", "毕月乌", "觜火猴", "参水猿",
+			"井木犴", "鬼金羊", "柳土獐", "星日马", "张月鹿", "翼火蛇", "轸水蚓",
+		},
+		{
+			"角木蛟", "亢金龍", "氐土貉", "房日兔", "心月狐", "尾火虎", "箕水豹",
+			"斗木獬", "牛金牛", "女土蝠", "虛日鼠", "危月燕", "室火豬", "壁水貐",
+			"奎木狼", "婁金狗", "胃土雉", "昴日雞", "畢月烏", "觜火猴", "參水猿",
+			"井木犴", "鬼金羊", "柳土獐", "星日馬", "張月鹿", "翼火蛇", "軫水蚓",
+		},
+	}
+	directionAliases = [][]string{
+		{"正北", "东北", "正东", "东南", "正南", "西南", "正西", "西北", "中"},
+		{"正北", "東北", "正東", "東南", "正南", "西南", "正西", "西北", "中"},
+	}
+	pengZuGanAliases = [][]string{
+		{
+			"甲不开仓财物耗散", "乙不栽植千株不长", "丙不修灶必见灾殃", "丁不剃头头必生疮", "戊不受田田主不祥",
+			"己不破券二比并亡", "庚不经络织机虚张", "辛不合酱主人不尝", "壬不泱水更难提防", "癸不词讼理弱敌强",
+		},
+		{
+			"甲不開倉財物耗散", "乙不栽植千株不長", "丙不修灶必見災殃", "丁不剃頭頭必生瘡", "戊不受田田主不祥",
+			"己不破券二比並亡", "庚不經絡織機虛張", "辛不合醬主人不嘗", "壬不泱水更難提防", "癸不詞訟理弱敵強",
+		},
+	}
+	pengZuZhiAliases = [][]string{
+		{
+			"子不问卜自惹祸殃", "丑不冠带主不还乡", "寅不祭祀神鬼不尝", "卯不穿井水泉不香",
+			"辰不哭泣必主重丧", "巳不远行财物伏藏", "午不苫盖屋主更张", "未不服药毒气入肠",
+			"申不安床鬼祟入房", "酉不会客醉坐颠狂", "戌不吃犬作怪上床", "亥不嫁娶不利新郎",
+		},
+		{
+			"子不問卜自惹禍殃", "丑不冠帶主不還鄉", "寅不祭祀神鬼不嘗", "卯不穿井水泉不香",
+			"辰不哭泣必主重喪", "巳不遠行財物伏藏", "午不苫蓋屋主更張", "未不服藥毒氣入腸",
+			"申不安床鬼祟入房", "酉不會客醉坐顛狂", "戌不吃犬作怪上床", "亥不嫁娶不利新郎",
+		},
+	}
+	fetusPositionAliases = [][]string{
+		{
+			"占门碓外东南", "碓磨厕外东南", "厨灶炉外正南", "仓库门外正南", "房床栖外正南", "占门床外正南",
+			"占碓磨外正南", "厨灶厕外西南", "仓库炉外西南", "房床门外西南", "门鸡栖外西南", "碓磨床外西南",
+			"厨灶碓外西南", "仓库厕外正西", "房床炉外正西", "大门外正西", "碓磨栖外正西", "厨灶床外正西",
+			"仓库碓外西北", "房床厕外西北", "占门炉外西北", "碓磨门外西北", "厨灶栖外西北", "仓库床外西北",
+			"房床碓外正北", "占门厕外正北", "碓磨炉外正北", "厨灶门外正北", "仓库栖外正北", "占房床房内北",
+			"占门碓房内北", "碓磨厕房内北", "厨灶炉房内北", "仓库门房内北", "房床栖房内中", "占门床房内中",
+			"占碓磨房内南", "厨灶厕房内南", "仓库炉房内南", "房床门房内西", "门鸡栖房内东", "碓磨床房内东",
+			"厨灶碓房内东", "仓库厕房内东", "房床炉房内中", "大门外东北", "碓磨栖外东北", "厨灶床外东北",
+			"仓库碓外东北", "房床厕外东北", "占门炉外东北", "碓磨门外正东", "厨灶栖外正东", "仓库床外正东",
+			"房床碓外正东", "占门厕外正东", "碓磨炉外东南", "厨灶门外东南", "仓库栖外东南", "占房床外东南",
+		},
+		{
+			"占門碓外東南", "碓磨廁外東南", "廚灶爐外正南", "倉庫門外正南", "房床棲外正南", "占門床外正南",
+			"占碓磨外正南", "廚灶廁外西南", "倉庫爐外西南", "房床門外西南", "門雞棲外西南", "碓磨床外西南",
+			"廚灶碓外西南", "倉庫廁外正西", "房床爐外正西", "大門外正西", "碓磨棲外正西", "廚灶床外正西",
+			"倉庫碓外西北", "房床廁外西北", "占門爐外西北", "碓磨門外西北", "廚灶棲外西北", "倉庫床外西北",
+			"房床碓外正北", "占門廁外正北", "碓磨爐外正北", "廚灶門外正北", "倉庫棲外正北", "占房床房內北",
+			"占門碓房內北", "碓磨廁房內北", "廚灶爐房內北", "倉庫門房內北", "房床棲房內中", "占門床房內中",
+			"占碓磨房內南", "廚灶廁房內南", "倉庫爐房內南", "房床門房內西", "門雞棲房內東", "碓磨床房內東",
+			"廚灶碓房內東", "倉庫廁房內東", "房床爐房內中", "大門外東北", "碓磨棲外東北", "廚灶床外東北",
+			"倉庫碓外東北", "房床廁外東北", "占門爐外東北", "碓磨門外正東", "廚灶棲外正東", "倉庫床外正東",
+			"房床碓外正東", "占門廁外正東", "碓磨爐外東南", "廚灶門外東南", "倉庫棲外東南", "占房床外東南",
+		},
+	}
+	twelveStageAliases = [][]string{
+		{"长生", "沐浴", "冠带", "临官", "帝旺", "衰", "病", "死", "墓", "绝", "胎", "养"},
+		{"長生", "沐浴", "冠帶", "臨官", "帝旺", "衰", "病", "死", "墓", "絕", "胎", "養"},
+	}
+	shenShaAliases = [][]string{
+		{"天乙贵人", "文昌", "驿马", "桃花", "华盖", "禄神", "羊刃"},
+		{"天乙貴人", "文昌", "驛馬", "桃花", "華蓋", "祿神", "羊刃"},
+	}
+	seasonStateAliases = [][]string{
+		{"旺", "相", "休", "囚", "死"},
+		{"旺", "相", "休", "囚", "死"},
+	}
+)
+
+// Formats
+var (
+	rankDescriptionAliases = [][]string{
+		{"综合评分%d分（%s）：五格数理%d分，五行%d分，八字喜用%d分，喜用神为%s。"},
+		{"綜合評分%d分（%s）：五格數理%d分，五行%d分，八字喜用%d分，喜用神為%s。"},
+	}
+	clashDescriptionAliases = [][]string{
+		{"冲%s（%s）煞%s"},
+		{"沖%s（%s）煞%s"},
+	}
+	auspiciousReasonAliases = [][]string{
+		{
+			"日主得令", "日主失令", "日主得势", "日主失势", "日主得地", "日主失地",
+			"身强", "身弱", "五行缺%s", "五行俱全", "喜用神为%s", "姓名评分%d分",
+		},
+		{
+			"日主得令", "日主失令", "日主得勢", "日主失勢", "日主得地", "日主失地",
+			"身強", "身弱", "五行缺%s", "五行俱全", "喜用神為%s", "姓名評分%d分",
+		},
+	}
+	interactionAliases = [][]string{
+		{"%s%s合化%s", "%s%s合", "%s%s合%s", "%s%s冲", "%s%s刑", "%s%s害"},
+		{"%s%s合化%s", "%s%s合", "%s%s合%s", "%s%s沖", "%s%s刑", "%s%s害"},
+	}
+	icsSummaryAliases = [][]string{
+		{"农历生日（%s月%s）", "本命年（%s年）", "本年无%s月%s，以%s月%s代", "闰%s"},
+		{"農曆生日（%s月%s）", "本命年（%s年）", "本年無%s月%s，以%s月%s代", "閏%s"},
+	}
+	favourableReasonAliases = [][]string{
+		{
+			"身强，宜泄耗克身", "身弱，宜生扶日主",
+			"生于夏令%s月，火炎土燥，调候以水为急", "生于冬令%s月，金寒水冷，调候以火为急", "生于%s月，寒暖适中，不以调候取用",
+			"%s%.1f与%s%.1f相战，以%s通关", "五行无相战之势，不以通关取用",
+			"用神%s，喜%s，忌%s",
+		},
+		{
+			"身強，宜洩耗克身", "身弱，宜生扶日主",
+			"生於夏令%s月，火炎土燥，調候以水為急", "生於冬令%s月，金寒水冷，調候以火為急", "生於%s月，寒暖適中，不以調候取用",
+			"%s%.1f與%s%.1f相戰，以%s通關", "五行無相戰之勢，不以通關取用",
+			"用神%s，喜%s，忌%s",
+		},
+	}
 )
 
 // GetAlias : Get aliases text
@@ -200,6 +356,36 @@ func GetAlias(alias int, index int, language int) string {
 		aliases = solartermAliases
 	case AliasSoundFiveElement:
 		aliases = soundFiveElementAliases
+	case AliasRankDescription:
+		aliases = rankDescriptionAliases
+	case AliasOfficer:
+		aliases = officerAliases
+	case AliasMansion:
+		aliases = mansionAliases
+	case AliasDirection:
+		aliases = directionAliases
+	case AliasPengZuGan:
+		aliases = pengZuGanAliases
+	case AliasPengZuZhi:
+		aliases = pengZuZhiAliases
+	case AliasFetusPosition:
+		aliases = fetusPositionAliases
+	case AliasClashDescription:
+		aliases = clashDescriptionAliases
+	case AliasAuspiciousReason:
+		aliases = auspiciousReasonAliases
+	case AliasTwelveStage:
+		aliases = twelveStageAliases
+	case AliasShenSha:
+		aliases = shenShaAliases
+	case AliasFavourableReason:
+		aliases = favourableReasonAliases
+	case AliasSeasonState:
+		aliases = seasonStateAliases
+	case AliasInteraction:
+		aliases = interactionAliases
+	case AliasICSSummary:
+		aliases = icsSummaryAliases
 	}
 
 	if aliases == nil || len(aliases) < 1 {
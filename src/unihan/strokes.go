@@ -0,0 +1,284 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file strokes.go
+ * @package unihan
+ */
+
+package unihan
+
+import (
+	"sort"
+	"strconv"
+	"utils"
+)
+
+// Stroke policies
+const (
+	// StrokePolicyModern : Modern standard (GB) strokes as written
+	StrokePolicyModern = "modern"
+	// StrokePolicyKangxi : 康熙 strokes, radicals restored to original forms and numerals counted by value
+	StrokePolicyKangxi = "kangxi"
+	// StrokePolicyUnicode : Unicode radical-stroke counts
+	StrokePolicyUnicode = "unicode"
+	// StrokePolicyJapanese : Japanese radical-stroke counts, as 姓名判断 schools do
+	StrokePolicyJapanese = "japanese"
+	// StrokePolicyDefault : Policy if none given
+	StrokePolicyDefault = StrokePolicyKangxi
+)
+
+// Sources of stroke count
+const (
+	StrokeSourceNumeral  = "numeral"
+	StrokeSourceKangxi   = "kRSKangXi"
+	StrokeSourceUnicode  = "kRSUnicode"
+	StrokeSourceJapanese = "kRSJapanese"
+	StrokeSourceTotal    = "kTotalStrokes"
+)
+
+// radicalRestoration : Abbreviated form of Kangxi radical, for display only.
+// Radical strokes of radical-stroke counts are always of original form, so nothing to count here
+type radicalRestoration struct {
+	abbreviation string
+	original     string
+}
+
+// Kangxi radical index => restoration
+var radicalRestorations = map[int]radicalRestoration{
+	9:   {"亻", "人"},
+	18:  {"刂", "刀"},
+	61:  {"忄", "心"},
+	64:  {"扌", "手"},
+	85:  {"氵", "水"},
+	86:  {"灬", "火"},
+	94:  {"犭", "犬"},
+	96:  {"王", "玉"},
+	113: {"礻", "示"},
+	122: {"罒", "网"},
+	125: {"耂", "老"},
+	130: {"月", "肉"},
+	140: {"艹", "艸"},
+	145: {"衤", "衣"},
+	162: {"辶", "辵"},
+	163: {"阝", "邑"},
+	170: {"阝", "阜"},
+}
+
+// Numeral characters counted by value
+var numeralStrokes = map[rune]int{
+	'一': 1,
+	'二': 2,
+	'三': 3,
+	'四': 4,
+	'五': 5,
+	'六': 6,
+	'七': 7,
+	'八': 8,
+	'九': 9,
+	'十': 10,
+}
+
+// StrokeDerivation : How stroke count of character derived
+type StrokeDerivation struct {
+	Character        string `json:"character"`
+	Policy           string `json:"policy"`
+	Source           string `json:"source"`
+	Radical          int    `json:"radical,omitempty"`
+	RadicalStr       string `json:"radical_str,omitempty"`
+	RadicalStroke    int    `json:"radical_stroke,omitempty"`
+	AdditionalStroke int    `json:"additional_stroke,omitempty"`
+	Restored         string `json:"restored,omitempty"`
+	Stroke           int    `json:"stroke"`
+}
+
+var strokePolicies = map[string]func(c *HanCharacter) *StrokeDerivation{
+	StrokePolicyModern:   (*HanCharacter).QueryStrokeModern,
+	StrokePolicyKangxi:   (*HanCharacter).QueryStrokeKangxi,
+	StrokePolicyUnicode:  (*HanCharacter).QueryStrokeUnicode,
+	StrokePolicyJapanese: (*HanCharacter).QueryStrokeJapanese,
+}
+
+// IsStrokePolicy : If stroke policy of given name exists, empty for default
+func IsStrokePolicy(name string) bool {
+	if name == "" {
+		return true
+	}
+
+	_, ok := strokePolicies[name]
+
+	return ok
+}
+
+// ListStrokePolicies : Names of all stroke policies
+func ListStrokePolicies() []string {
+	var ret []string
+
+	for name := range strokePolicies {
+		ret = append(ret, name)
+	}
+
+	sort.Strings(ret)
+
+	return ret
+}
+
+// QueryStrokePolicy : Query stroke of character by policy, default policy if unknown
+func (c *HanCharacter) QueryStrokePolicy(policy string) *StrokeDerivation {
+	f, ok := strokePolicies[policy]
+	if !ok {
+		f = strokePolicies[StrokePolicyDefault]
+	}
+
+	return f(c)
+}
+
+// totalStroke : Stroke of character as written
+func (c *HanCharacter) totalStroke() int {
+	var stroke int
+
+	if c.DictionaryLikeDatas != nil &&
+		c.DictionaryLikeDatas["kTotalStrokes"] != nil {
+		stroke, _ = strconv.Atoi(c.DictionaryLikeDatas["kTotalStrokes"].Data)
+	}
+
+	return stroke
+}
+
+// QueryStrokeKangxi : Query 康熙 stroke of character, with derivation.
+// Counted by kRSKangXi (kRSUnicode if absent) radical in original form plus additional strokes,
+// numerals by value, and character itself a radical as written
+func (c *HanCharacter) QueryStrokeKangxi() *StrokeDerivation {
+	var (
+		ret = &StrokeDerivation{
+			Character: string(c.Unicode),
+			Policy:    StrokePolicyKangxi,
+		}
+		rs    *hanRSCount
+		total = c.totalStroke()
+	)
+
+	if n, ok := numeralStrokes[c.Unicode]; ok {
+		ret.Source = StrokeSourceNumeral
+		ret.Stroke = n
+
+		return ret
+	}
+
+	if c.RadicalStrokeCounts != nil {
+		for _, t := range []string{StrokeSourceKangxi, StrokeSourceUnicode} {
+			if c.RadicalStrokeCounts[t] != nil && c.RadicalStrokeCounts[t].Radical > 0 {
+				ret.Source = t
+				rs = c.RadicalStrokeCounts[t]
+
+				break
+			}
+		}
+	}
+
+	if rs == nil || (rs.RadicalAdditionalStrokeCount == 0 && total > 0) {
+		// Unknown radical, or radical itself
+		ret.Source = StrokeSourceTotal
+		ret.Stroke = total
+
+		return ret
+	}
+
+	ret.Radical = rs.Radical
+	ret.RadicalStr = utils.GetRadical(rs.Radical).Str
+	ret.RadicalStroke = rs.RadicalStrokeCount
+	ret.AdditionalStroke = rs.RadicalAdditionalStrokeCount
+	r, ok := radicalRestorations[rs.Radical]
+	if ok {
+		ret.RadicalStr = r.original
+	}
+
+	if total > 0 && total-rs.RadicalAdditionalStrokeCount < rs.RadicalStrokeCount {
+		// Radical written in fewer strokes than original form
+		if ok {
+			ret.Restored = r.abbreviation + "→" + r.original
+		} else {
+			ret.Restored = ret.RadicalStr
+		}
+	}
+
+	ret.Stroke = ret.RadicalStroke + ret.AdditionalStroke
+
+	return ret
+}
+
+// QueryStrokeModern : Query modern standard stroke of character, as written
+func (c *HanCharacter) QueryStrokeModern() *StrokeDerivation {
+	return &StrokeDerivation{
+		Character: string(c.Unicode),
+		Policy:    StrokePolicyModern,
+		Source:    StrokeSourceTotal,
+		Stroke:    c.totalStroke(),
+	}
+}
+
+// queryStrokeRS : Stroke of character by radical-stroke property, total stroke if absent
+func (c *HanCharacter) queryStrokeRS(policy, property string) *StrokeDerivation {
+	var ret = &StrokeDerivation{
+		Character: string(c.Unicode),
+		Policy:    policy,
+	}
+
+	if c.RadicalStrokeCounts == nil ||
+		c.RadicalStrokeCounts[property] == nil ||
+		c.RadicalStrokeCounts[property].Radical == 0 {
+		ret.Source = StrokeSourceTotal
+		ret.Stroke = c.totalStroke()
+
+		return ret
+	}
+
+	rs := c.RadicalStrokeCounts[property]
+	ret.Source = property
+	ret.Radical = rs.Radical
+	ret.RadicalStr = rs.RadicalStr
+	ret.RadicalStroke = rs.RadicalStrokeCount
+	ret.AdditionalStroke = rs.RadicalAdditionalStrokeCount
+	ret.Stroke = ret.RadicalStroke + ret.AdditionalStroke
+
+	return ret
+}
+
+// QueryStrokeUnicode : Query stroke of character by kRSUnicode
+func (c *HanCharacter) QueryStrokeUnicode() *StrokeDerivation {
+	return c.queryStrokeRS(StrokePolicyUnicode, StrokeSourceUnicode)
+}
+
+// QueryStrokeJapanese : Query stroke of character by kRSJapanese
+func (c *HanCharacter) QueryStrokeJapanese() *StrokeDerivation {
+	return c.queryStrokeRS(StrokePolicyJapanese, StrokeSourceJapanese)
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
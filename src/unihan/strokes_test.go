@@ -0,0 +1,103 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file strokes_test.go
+ * @package unihan
+ */
+
+package unihan
+
+import (
+	"testing"
+)
+
+// testCharacter : Character with radical-stroke and total stroke properties only
+func testCharacter(c rune, property, rs, total string) *HanCharacter {
+	var ret = &HanCharacter{
+		Unicode: c,
+		Utf8Str: string(c),
+	}
+
+	ret.appendProperty(property, rs)
+	ret.appendProperty("kTotalStrokes", total)
+
+	return ret
+}
+
+func TestQueryStrokeKangxi(t *testing.T) {
+	var cases = []struct {
+		c        rune
+		property string
+		rs       string
+		total    string
+		source   string
+		stroke   int
+		restored string
+	}{
+		{'河', "kRSKangXi", "85.5", "8", StrokeSourceKangxi, 9, "氵→水"},
+		{'理', "kRSKangXi", "96.7", "11", StrokeSourceKangxi, 12, "王→玉"},
+		{'芳', "kRSKangXi", "140.4", "7", StrokeSourceKangxi, 10, "艹→艸"},
+		{'陳', "kRSKangXi", "170.8", "11", StrokeSourceKangxi, 16, "阝→阜"},
+		{'鄭', "kRSKangXi", "163.12", "15", StrokeSourceKangxi, 19, "阝→邑"},
+		{'四', "kRSKangXi", "31.2", "5", StrokeSourceNumeral, 4, ""},
+		{'林', "kRSKangXi", "75.4", "8", StrokeSourceKangxi, 8, ""},
+	}
+
+	for _, cs := range cases {
+		d := testCharacter(cs.c, cs.property, cs.rs, cs.total).QueryStrokeKangxi()
+		if d.Source != cs.source || d.Stroke != cs.stroke || d.Restored != cs.restored {
+			t.Errorf("%c : got %s %d <%s>, want %s %d <%s>",
+				cs.c, d.Source, d.Stroke, d.Restored, cs.source, cs.stroke, cs.restored)
+		}
+	}
+}
+
+func TestQueryStrokeModern(t *testing.T) {
+	var cases = []struct {
+		c      rune
+		rs     string
+		total  string
+		stroke int
+	}{
+		{'河', "85.5", "8", 8},
+		{'陈', "170.5", "7", 7},
+		{'四', "31.2", "5", 5},
+	}
+
+	for _, cs := range cases {
+		d := testCharacter(cs.c, "kRSUnicode", cs.rs, cs.total).QueryStrokeModern()
+		if d.Stroke != cs.stroke {
+			t.Errorf("%c : got %d, want %d", cs.c, d.Stroke, cs.stroke)
+		}
+	}
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -77,6 +77,52 @@ type FiveElementsCount struct {
 	Water int `json:"water"`
 }
 
+// Add : Add n to count of element
+func (c *FiveElementsCount) Add(element, n int) {
+	switch element {
+	case ElementWood:
+		c.Wood += n
+	case ElementFire:
+		c.Fire += n
+	case ElementEarth:
+		c.Earth += n
+	case ElementMetal:
+		c.Metal += n
+	case ElementWater:
+		c.Water += n
+	}
+}
+
+// FiveElementsWeight : Weighted strength of five elements
+type FiveElementsWeight struct {
+	Wood  float64 `json:"wood"`
+	Fire  float64 `json:"fire"`
+	Earth float64 `json:"earth"`
+	Metal float64 `json:"metal"`
+	Water float64 `json:"water"`
+}
+
+// Add : Add w to weight of element
+func (fw *FiveElementsWeight) Add(element int, w float64) {
+	switch element {
+	case ElementWood:
+		fw.Wood += w
+	case ElementFire:
+		fw.Fire += w
+	case ElementEarth:
+		fw.Earth += w
+	case ElementMetal:
+		fw.Metal += w
+	case ElementWater:
+		fw.Water += w
+	}
+}
+
+// Values : Weights in order of Wood, Fire, Earth, Metal and Water
+func (fw *FiveElementsWeight) Values() []float64 {
+	return []float64{fw.Wood, fw.Fire, fw.Earth, fw.Metal, fw.Water}
+}
+
 // CompareFiveElements : Compare five-elements - Wood->Fire->Earth->Metal->Water
 func CompareFiveElements(fe1, fe2 int) int {
 	if fe1 < fe2 {
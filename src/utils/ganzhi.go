@@ -87,6 +87,55 @@ const (
 	ZhiHai
 )
 
+// Twelve stages (十二长生) of tiangan
+const (
+	// StageChangSheng : 长生
+	StageChangSheng = iota
+	// StageMuYu : 沐浴
+	StageMuYu
+	// StageGuanDai : 冠带
+	StageGuanDai
+	// StageLinGuan : 临官
+	StageLinGuan
+	// StageDiWang : 帝旺
+	StageDiWang
+	// StageShuai : 衰
+	StageShuai
+	// StageBing : 病
+	StageBing
+	// StageSi : 死
+	StageSi
+	// StageMu : 墓
+	StageMu
+	// StageJue : 绝
+	StageJue
+	// StageTai : 胎
+	StageTai
+	// StageYang : 养
+	StageYang
+)
+
+var (
+	// Hidden tiangan (藏干) of dizhi, primary first
+	zhiHiddenGans = [][]int{
+		{GanGui},                  // 子
+		{GanJi, GanGui, GanXin},   // 丑
+		{GanJia, GanBing, GanWu},  // 寅
+		{GanYi},                   // 卯
+		{GanWu, GanYi, GanGui},    // 辰
+		{GanBing, GanWu, GanGeng}, // 巳
+		{GanDing, GanJi},          // 午
+		{GanJi, GanDing, GanYi},   // 未
+		{GanGeng, GanRen, GanWu},  // 申
+		{GanXin},                  // 酉
+		{GanWu, GanXin, GanDing},  // 戌
+		{GanRen, GanJia},          // 亥
+	}
+
+	// Dizhi of ChangSheng of tiangan
+	ganChangSheng = []int{ZhiHai, ZhiWu, ZhiYin, ZhiYou, ZhiYin, ZhiYou, ZhiSi, ZhiZi, ZhiShen, ZhiMao}
+)
+
 // GanFiveElement : Five-element of tiangan
 func GanFiveElement(gan int) int {
 	switch gan {
@@ -141,6 +190,28 @@ func ZhiYinYang(zhi int) int {
 	return YinYangYin
 }
 
+// ZhiHiddenGans : Hidden tiangan of dizhi, primary, middle and residual
+func ZhiHiddenGans(zhi int) []int {
+	if zhi < 0 || zhi >= 12 {
+		return nil
+	}
+
+	return zhiHiddenGans[zhi]
+}
+
+// GanStage : Twelve stage of tiangan in dizhi, forward for yang and backward for yin
+func GanStage(gan, zhi int) int {
+	if gan < 0 || gan >= 10 || zhi < 0 || zhi >= 12 {
+		return -1
+	}
+
+	if GanYinYang(gan) == YinYangYang {
+		return (zhi - ganChangSheng[gan] + 12) % 12
+	}
+
+	return (ganChangSheng[gan] - zhi + 12) % 12
+}
+
 // GanzhiPair : TianGan0DiZhi
 type GanzhiPair struct {
 	TianGan int `json:"tian_gan"`
@@ -186,6 +257,17 @@ func (gz *GanzhiPair) String(language int) string {
 		texts.GetAlias(texts.AliasZhi, gz.DiZhi, language))
 }
 
+// Kongwang : Two empty dizhi (空亡) of the xun of GanzhiPair
+func (gz *GanzhiPair) Kongwang() (int, int) {
+	if !gz.valid() {
+		return -1, -1
+	}
+
+	first := (gz.DiZhi - gz.TianGan + 10 + 12) % 12
+
+	return first, (first + 1) % 12
+}
+
 // ParseGanzhi : Parse int to GanzhiPair
 func ParseGanzhi(v int) *GanzhiPair {
 	return &GanzhiPair{
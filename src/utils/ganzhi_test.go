@@ -0,0 +1,127 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file ganzhi_test.go
+ * @package utils
+ */
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestZhiHiddenGans(t *testing.T) {
+	var cases = []struct {
+		zhi  int
+		gans []int
+	}{
+		{ZhiZi, []int{GanGui}},
+		{ZhiChou, []int{GanJi, GanGui, GanXin}},
+		{ZhiYin, []int{GanJia, GanBing, GanWu}},
+		{ZhiChen, []int{GanWu, GanYi, GanGui}},
+		{ZhiWu, []int{GanDing, GanJi}},
+		{ZhiShen, []int{GanGeng, GanRen, GanWu}},
+		{ZhiXu, []int{GanWu, GanXin, GanDing}},
+		{ZhiHai, []int{GanRen, GanJia}},
+		{12, nil},
+		{-1, nil},
+	}
+
+	for _, c := range cases {
+		if got := ZhiHiddenGans(c.zhi); !reflect.DeepEqual(got, c.gans) {
+			t.Errorf("%d : got %v, want %v", c.zhi, got, c.gans)
+		}
+	}
+}
+
+func TestGanStage(t *testing.T) {
+	var cases = []struct {
+		gan   int
+		zhi   int
+		stage int
+	}{
+		{GanJia, ZhiHai, StageChangSheng},
+		{GanJia, ZhiMao, StageDiWang},
+		{GanJia, ZhiWu, StageSi},
+		{GanJia, ZhiWei, StageMu},
+		{GanYi, ZhiWu, StageChangSheng},
+		{GanYi, ZhiYin, StageDiWang},
+		{GanYi, ZhiXu, StageMu},
+		{GanBing, ZhiWu, StageDiWang},
+		{GanWu, ZhiYin, StageChangSheng},
+		{GanJi, ZhiYou, StageChangSheng},
+		{GanGeng, ZhiSi, StageChangSheng},
+		{GanGeng, ZhiYou, StageDiWang},
+		{GanXin, ZhiShen, StageDiWang},
+		{GanRen, ZhiZi, StageDiWang},
+		{GanGui, ZhiMao, StageChangSheng},
+		{GanGui, ZhiHai, StageDiWang},
+		{GanGui, ZhiWei, StageMu},
+		{10, ZhiZi, -1},
+		{GanJia, -1, -1},
+	}
+
+	for _, c := range cases {
+		if got := GanStage(c.gan, c.zhi); got != c.stage {
+			t.Errorf("%d in %d : got %d, want %d", c.gan, c.zhi, got, c.stage)
+		}
+	}
+}
+
+func TestKongwang(t *testing.T) {
+	var cases = []struct {
+		pillar GanzhiPair
+		first  int
+		second int
+	}{
+		// 甲子旬
+		{GanzhiPair{GanJia, ZhiZi}, ZhiXu, ZhiHai},
+		{GanzhiPair{GanBing, ZhiYin}, ZhiXu, ZhiHai},
+		// 甲戌旬
+		{GanzhiPair{GanGui, ZhiWei}, ZhiShen, ZhiYou},
+		// 甲午旬
+		{GanzhiPair{GanWu, ZhiXu}, ZhiChen, ZhiSi},
+		// 甲寅旬
+		{GanzhiPair{GanGui, ZhiHai}, ZhiZi, ZhiChou},
+		{GanzhiPair{GanJia, ZhiChou}, -1, -1},
+	}
+
+	for _, c := range cases {
+		first, second := c.pillar.Kongwang()
+		if first != c.first || second != c.second {
+			t.Errorf("%v : got %d %d, want %d %d", c.pillar, first, second, c.first, c.second)
+		}
+	}
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
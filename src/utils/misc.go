@@ -78,6 +78,11 @@ func FixMod(i, m int) int {
 	return r
 }
 
+// Mod : Non-negative remainder of i divided by m, for cyclic indexes of years before the era
+func Mod(i, m int) int {
+	return ((i % m) + m) % m
+}
+
 /*
  * Local variables:
  * tab-width: 4